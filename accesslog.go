@@ -0,0 +1,97 @@
+package sgsr
+
+import (
+	"log/slog"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"github.com/valyala/fasthttp"
+)
+
+// AccessLog configures request logging. The first rule matching a request
+// decides its sample rate; requests matching no rule use DefaultRate. Query
+// adds the query string, with values redacted as set up by WithRedaction.
+type AccessLog struct {
+	Rules       []SampleRule
+	DefaultRate float64
+	Query       bool
+}
+
+// SampleRule matches requests by path prefix and an inclusive status range;
+// zero values match everything. Rate is the fraction of matching requests
+// logged, from 0 (none) to 1 (all).
+type SampleRule struct {
+	PathPrefix string
+	MinStatus  int
+	MaxStatus  int
+	Rate       float64
+}
+
+// DefaultAccessLog logs every request.
+func DefaultAccessLog() AccessLog {
+	return AccessLog{DefaultRate: 1}
+}
+
+func (c Config) WithAccessLog(l AccessLog) Config {
+	c.accessLog = &l
+	return c
+}
+
+func (r SampleRule) matches(path string, status int) bool {
+	return strings.HasPrefix(path, r.PathPrefix) &&
+		(r.MinStatus == 0 || status >= r.MinStatus) &&
+		(r.MaxStatus == 0 || status <= r.MaxStatus)
+}
+
+func (l AccessLog) sampled(path string, status int) bool {
+	rate := l.DefaultRate
+	for _, r := range l.Rules {
+		if r.matches(path, status) {
+			rate = r.Rate
+			break
+		}
+	}
+	return rate >= 1 || rate > 0 && rand.Float64() < rate
+}
+
+func (a *App) accessLogHandler(next fasthttp.RequestHandler) fasthttp.RequestHandler {
+	l := *a.cfg.accessLog
+	return func(ctx *fasthttp.RequestCtx) {
+		started := time.Now()
+		next(ctx)
+
+		status := ctx.Response.StatusCode()
+		path := string(ctx.Path())
+		if !l.sampled(path, status) {
+			return
+		}
+
+		level := slog.LevelInfo
+		if status >= fasthttp.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		attrs := []slog.Attr{
+			slog.String("method", string(ctx.Method())),
+			slog.String("path", path),
+			slog.Int("status", status),
+			slog.Duration("duration", time.Since(started)),
+			slog.Int("bytes", responseSize(&ctx.Response)),
+			slog.String("client", ctx.RemoteIP().String()),
+		}
+		if l.Query && ctx.QueryArgs().Len() > 0 {
+			attrs = append(attrs, slog.String("query", a.cfg.redact.query(ctx.QueryArgs())))
+		}
+		a.accessLog.LogAttrs(ctx, level, "Request", attrs...)
+	}
+}
+
+// responseSize is the body size without draining a body stream, which would
+// buffer it instead of sending it to the client. Streams report their
+// Content-Length, -1 when chunked.
+func responseSize(resp *fasthttp.Response) int {
+	if resp.IsBodyStream() {
+		return resp.Header.ContentLength()
+	}
+	return len(resp.Body())
+}
@@ -0,0 +1,59 @@
+package sgsr
+
+import (
+	"bufio"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// The access and slow request logs must not buffer streamed bodies.
+func TestStreamedResponseWithStandardMiddleware(t *testing.T) {
+	a, fa := newTestApp(t, func(c Config) Config {
+		return c.WithStandardMiddleware().WithSlowRequestLog(time.Nanosecond)
+	})
+	release := make(chan struct{})
+	var releaseOnce sync.Once
+	unblock := func() { releaseOnce.Do(func() { close(release) }) }
+	t.Cleanup(unblock)
+	fa.Get("/events", func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderContentType, "text/event-stream")
+		c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+			_, _ = w.WriteString("data: first\n\n")
+			_ = w.Flush()
+			<-release
+		})
+		return nil
+	})
+
+	if err := a.Start(); err != nil {
+		t.Fatal(err)
+	}
+	line := make(chan string, 1)
+	go func() {
+		resp, err := http.Get("http://" + a.Addr().String() + "/events")
+		if err != nil {
+			line <- err.Error()
+			return
+		}
+		defer resp.Body.Close()
+		s, _ := bufio.NewReader(resp.Body).ReadString('\n')
+		line <- s
+	}()
+	select {
+	case s := <-line:
+		if s != "data: first\n" {
+			t.Fatalf("first line = %q", s)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("no streamed data received")
+	}
+
+	unblock()
+	if err := stopApp(t, a); err != nil {
+		t.Fatal(err)
+	}
+}
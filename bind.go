@@ -0,0 +1,70 @@
+package sgsr
+
+import (
+	"context"
+	"errors"
+	"net"
+	"slices"
+	"time"
+)
+
+// BindRetry retries binding the listener, e.g. while the previous instance
+// still holds the port after a restart. Each attempt tries the address and
+// then the fallback addresses in order.
+type BindRetry struct {
+	Attempts int
+	Delay    time.Duration
+}
+
+func (c Config) WithBindRetry(r BindRetry) Config {
+	c.bindRetry = r
+	return c
+}
+
+// WithFallbackAddrs adds addresses tried in order when the configured one
+// cannot be bound. App.Addr reports the address actually used.
+func (c Config) WithFallbackAddrs(addrs ...string) Config {
+	c.fallbackAddrs = append(slices.Clip(c.fallbackAddrs), addrs...)
+	return c
+}
+
+// bind gives up with ErrStartupTimeout once the startup timeout, which
+// includes the retry delays, elapses.
+func (a *App) bind(ctx context.Context) (net.Listener, error) {
+	if a.cfg.startupTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, a.startedAt.Add(a.cfg.startupTimeout))
+		defer cancel()
+	}
+
+	network := a.cfg.app.Config().Network
+	addrs := append([]string{a.cfg.addr}, a.cfg.fallbackAddrs...)
+	lc := net.ListenConfig{}
+
+	for attempt := 0; ; attempt++ {
+		var errs []error
+		for i, addr := range addrs {
+			ln, err := lc.Listen(ctx, network, addr)
+			if err == nil {
+				if i > 0 {
+					a.cfg.logger.Warn("Bound fallback address", "addr", addr, "errors", errors.Join(errs...))
+				}
+				return ln, nil
+			}
+			errs = append(errs, err)
+		}
+
+		if attempt+1 >= a.cfg.bindRetry.Attempts {
+			return nil, errors.Join(errs...)
+		}
+		a.cfg.logger.Warn("Bind failed, retrying", "attempt", attempt+1, "delay", a.cfg.bindRetry.Delay, "error", errs[len(errs)-1])
+		select {
+		case <-time.After(a.cfg.bindRetry.Delay):
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return nil, errors.Join(append(errs, ErrStartupTimeout)...)
+			}
+			return nil, errors.Join(append(errs, ctx.Err())...)
+		}
+	}
+}
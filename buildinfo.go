@@ -0,0 +1,77 @@
+package sgsr
+
+import (
+	"log/slog"
+	"runtime/debug"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// BuildInfo holds caller-provided build metadata. Empty Commit and BuildDate
+// are filled from the VCS settings embedded by the Go toolchain.
+type BuildInfo struct {
+	Version   string            `json:"version,omitempty"`
+	Commit    string            `json:"commit,omitempty"`
+	BuildDate string            `json:"build_date,omitempty"`
+	Extra     map[string]string `json:"extra,omitempty"`
+
+	GoVersion     string `json:"go_version,omitempty"`
+	Module        string `json:"module,omitempty"`
+	ModuleVersion string `json:"module_version,omitempty"`
+	Modified      bool   `json:"modified,omitempty"`
+}
+
+func (b BuildInfo) resolve() BuildInfo {
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return b
+	}
+
+	b.GoVersion = bi.GoVersion
+	b.Module = bi.Main.Path
+	b.ModuleVersion = bi.Main.Version
+	for _, s := range bi.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			if b.Commit == "" {
+				b.Commit = s.Value
+			}
+		case "vcs.time":
+			if b.BuildDate == "" {
+				b.BuildDate = s.Value
+			}
+		case "vcs.modified":
+			b.Modified = s.Value == "true"
+		}
+	}
+	return b
+}
+
+func (b BuildInfo) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.String("version", b.Version),
+		slog.String("commit", b.Commit),
+		slog.String("build_date", b.BuildDate),
+		slog.String("go_version", b.GoVersion),
+	}
+	for k, v := range b.Extra {
+		attrs = append(attrs, slog.String(k, v))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// RegisterBuildInfo serves info, completed from runtime/debug.ReadBuildInfo,
+// as JSON on path.
+func RegisterBuildInfo(router fiber.Router, path string, info BuildInfo) {
+	info = info.resolve()
+	router.Get(path, func(c *fiber.Ctx) error {
+		return c.JSON(info)
+	})
+}
+
+// WithBuildInfo adds info to the startup log.
+func (c Config) WithBuildInfo(info BuildInfo) Config {
+	info = info.resolve()
+	c.buildInfo = &info
+	return c
+}
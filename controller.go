@@ -2,28 +2,91 @@ package sgsr
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
 	"log/slog"
+	"net"
 	"os"
 	"os/signal"
+	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
 )
 
+const defaultShutdownTimeout = 30 * time.Second
+
 type Config struct {
-	app    *fiber.App
-	logger *slog.Logger
-	ctx    context.Context
-	addr   string
+	app       *fiber.App
+	logger    *slog.Logger
+	ctx       context.Context
+	addr      string
+	tlsConfig *tls.Config
+	certFile  string
+	keyFile   string
+
+	fallbackAddrs []string
+	bindRetry     BindRetry
+
+	getCertificate func(*tls.ClientHelloInfo) (*tls.Certificate, error)
+	tlsReload      time.Duration
+
+	clientCAs    *x509.CertPool
+	verifyClient func(cert *x509.Certificate) error
+
+	signals           []os.Signal
+	preShutdownDelay  time.Duration
+	shutdownTimeout   time.Duration
+	upgradeSignal     os.Signal
+	restart           *RestartPolicy
+	stopOnWorkerError bool
+	startupTimeout    time.Duration
+	reloadSignal      os.Signal
+	prefork           int
+
+	livenessPath  string
+	readinessPath string
+	startupPath   string
+	healthPath    string
+	verboseHealth bool
+
+	recover     bool
+	requestID   bool
+	accessLog   *AccessLog
+	slowRequest time.Duration
+	hsts        *HSTS
+	buildInfo   *BuildInfo
+	redact      redactor
+
+	startupSummary bool
+
+	serverHeader    *string
+	stripHeaders    []string
+	securityHeaders bool
+
+	drainConnClose bool
+	drainRejection *DrainRejection
+
+	preflight []PreflightCheck
+
+	componentLevels map[string]slog.Leveler
+	requestContext  *func(c *fiber.Ctx) context.Context
 }
 
 func NewConfig(l *slog.Logger, app *fiber.App, addr string) Config {
 	return Config{
-		ctx:    context.Background(),
-		logger: l,
-		app:    app,
-		addr:   addr,
+		ctx:             context.Background(),
+		logger:          l,
+		app:             app,
+		addr:            addr,
+		shutdownTimeout: defaultShutdownTimeout,
+
+		stopOnWorkerError: true,
 	}
 }
 
@@ -32,40 +95,462 @@ func (c Config) WithContext(ctx context.Context) Config {
 	return c
 }
 
+// WithSignals replaces the default SIGINT/SIGTERM set. Calling it with no
+// signals disables signal handling; use App.Shutdown to stop the app.
+func (c Config) WithSignals(sigs ...os.Signal) Config {
+	c.signals = append([]os.Signal{}, sigs...)
+	return c
+}
+
+// WithShutdownTimeout bounds how long graceful shutdown waits for open
+// connections before Run gives up with ErrShutdownTimeout.
+func (c Config) WithShutdownTimeout(d time.Duration) Config {
+	c.shutdownTimeout = d
+	return c
+}
+
+// WithStartupTimeout makes Run fail with ErrStartupTimeout when binding the
+// listener and running the OnStart hooks take longer than d.
+func (c Config) WithStartupTimeout(d time.Duration) Config {
+	c.startupTimeout = d
+	return c
+}
+
+// WithPreShutdownDelay keeps serving for d after shutdown is triggered, while
+// the app already reports Draining, so load balancers can deregister it.
+func (c Config) WithPreShutdownDelay(d time.Duration) Config {
+	c.preShutdownDelay = d
+	return c
+}
+
+// notifyContext is cancelled by the configured signals, recording which one
+// arrived as the shutdown cause.
+func (a *App) notifyContext() (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(a.cfg.ctx)
+	sigs := a.cfg.signals
+	if sigs == nil {
+		sigs = []os.Signal{syscall.SIGINT, syscall.SIGTERM}
+	}
+	if len(sigs) == 0 {
+		return ctx, cancel
+	}
+
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, sigs...)
+	go func() {
+		select {
+		case sig := <-ch:
+			a.setCause(CauseSignal, sig, nil)
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	return ctx, func() {
+		signal.Stop(ch)
+		cancel()
+	}
+}
+
 type App struct {
-	cfg Config
+	cfg    Config
+	ctx    context.Context
+	cancel context.CancelFunc
+	custom net.Listener
+	tcp    net.Listener
+	ln     net.Listener
+
+	startHooks    []startHook
+	shutdownHooks []shutdownHook
+	reloadHooks   []reloadHook
+	servers       []*hostedServer
+	reloadMu      sync.Mutex
+	certs         *certReloader
+	inflight      inflight
+	panics        atomic.Uint64
+	events        events
+	workers       workers
+	drain         drain
+	baseLog       *slog.Logger
+	accessLog     *slog.Logger
+	tlsLog        *slog.Logger
+	report        report
+	startedAt     time.Time
+
+	quit     chan struct{}
+	quitOnce sync.Once
+
+	addr          atomic.Value
+	listening     chan struct{}
+	listeningOnce sync.Once
+	running       chan struct{}
+	done          chan struct{}
+	err           error
+	state         atomic.Int32
+
+	healthMu     sync.RWMutex
+	healthChecks []*healthCheck
+	startup      startupTasks
 }
 
 func NewApp(config Config) *App {
-	return &App{cfg: config}
+	ctx, cancel := context.WithCancel(config.ctx)
+	baseLog := config.logger
+	var accessLog, tlsLog *slog.Logger
+	if config.logger != nil {
+		accessLog = config.componentLogger(ComponentAccess)
+		tlsLog = config.componentLogger(ComponentTLS)
+		config.logger = config.componentLogger(ComponentLifecycle)
+	}
+	return &App{
+		cfg:       config,
+		baseLog:   baseLog,
+		accessLog: accessLog,
+		tlsLog:    tlsLog,
+		ctx:       ctx,
+		cancel:    cancel,
+		quit:      make(chan struct{}),
+		done:      make(chan struct{}),
+		listening: make(chan struct{}),
+		running:   make(chan struct{}),
+		drain:     drain{ch: make(chan struct{})},
+	}
 }
 
-func NewLogger() *slog.Logger {
-	return slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{}))
-}
+func (a *App) Run() (err error) {
+	var stopping time.Time
+	defer func() {
+		a.cancel()
+		a.err = err
+		a.logShutdownReport(stopping)
+		a.setState(Stopped)
+		a.emit(Event{Type: EventStopped, Err: err})
+		a.closeEvents()
+		close(a.done)
+	}()
+
+	if err := a.cfg.Validate(); err != nil {
+		if a.cfg.logger != nil {
+			a.cfg.logger.Error(err.Error())
+		}
+		return fmt.Errorf("%w: %w", ErrStartup, err)
+	}
+
+	if a.cfg.prefork > 0 {
+		if !isPreforkChild() {
+			return a.runPreforkParent()
+		}
+		a.preforkChild()
+	}
 
-func (a App) Run() {
-	ctx, stop := signal.NotifyContext(a.cfg.ctx, syscall.SIGINT, syscall.SIGTERM)
+	a.startedAt = time.Now()
+	a.setState(Starting)
+	ctx, stop := a.notifyContext()
 	defer stop()
 
 	go func() {
-		<-ctx.Done()
-		stop()
-		a.cfg.logger.Info("Trying to shut down gracefully")
+		select {
+		case <-a.quit:
+			stop()
+		case <-ctx.Done():
+		}
+	}()
 
-		go func() {
-			time.Sleep(time.Second * 30)
-			a.cfg.logger.Error("Exit by shut down timeout")
-			os.Exit(3)
-		}()
+	if err := a.runPreflight(ctx); err != nil {
+		a.setCause(CauseStartup, nil, err)
+		return fmt.Errorf("%w: %w", ErrStartup, err)
+	}
 
-		_ = a.cfg.app.Shutdown()
-	}()
+	ln, err := a.listen(ctx)
+	if err != nil {
+		a.cfg.logger.Error(err.Error())
+		a.setCause(CauseStartup, nil, err)
+		return fmt.Errorf("%w: %w", ErrStartup, err)
+	}
+	if err := a.listenServers(); err != nil {
+		_ = ln.Close()
+		a.cfg.logger.Error(err.Error())
+		a.setCause(CauseStartup, nil, err)
+		return fmt.Errorf("%w: %w", ErrStartup, err)
+	}
+	a.ln = &onceCloseListener{Listener: ln}
 
-	a.cfg.logger.Info("Status", "Listening addr", a.cfg.addr)
+	a.wrapHandler()
+	a.setListening(ln.Addr())
+	if a.cfg.buildInfo != nil {
+		a.cfg.logger.Info("Build", "info", *a.cfg.buildInfo)
+	}
+	a.cfg.logger.Info("Status", "Listening addr", ln.Addr().String())
+	if a.cfg.startupSummary {
+		a.logStartupSummary()
+	}
+
+	served := make(chan error, 1)
+	go func() {
+		served <- a.cfg.app.Listener(a.ln)
+	}()
+	a.serveServers()
 
-	if err := a.cfg.app.Listen(a.cfg.addr); err != nil {
+	if err := a.start(ctx); err != nil {
 		a.cfg.logger.Error(err.Error())
-		panic(err)
+		a.setCause(CauseStartup, nil, err)
+		stopping = time.Now()
+		_ = a.shutdown()
+		<-served
+		return fmt.Errorf("%w: %w", ErrStartup, err)
+	}
+	a.setRunning()
+	notifyUpgradeReady()
+	a.startWorkers()
+
+	if a.cfg.upgradeSignal != nil {
+		go a.watchUpgrade(ctx)
+	}
+	if len(a.reloadHooks) > 0 || a.cfg.reloadSignal != nil || a.certs != nil {
+		go a.watchReload(ctx)
+	}
+
+	attempt, serving := 0, time.Now()
+serve:
+	for {
+		select {
+		case err := <-served:
+			if err == nil {
+				a.setCause(CauseListener, nil, nil)
+				return nil
+			}
+			a.cfg.logger.Error(err.Error())
+			if a.cfg.restart != nil && time.Since(serving) >= a.cfg.restart.MaxBackoff {
+				attempt = 0
+			}
+			if a.restartServe(ctx, err, attempt, served) {
+				attempt, serving = attempt+1, time.Now()
+				continue
+			}
+			// A stop during the restart backoff has recorded its own cause.
+			if ctx.Err() != nil {
+				a.setCause(CauseContext, nil, context.Cause(ctx))
+				stopping = time.Now()
+				return a.shutdown()
+			}
+			a.setCause(CauseListener, nil, err)
+			return fmt.Errorf("%w: %w", ErrServe, err)
+		case <-ctx.Done():
+			a.setCause(CauseContext, nil, context.Cause(ctx))
+			stop()
+			break serve
+		}
+	}
+
+	stopping = time.Now()
+
+	if err := a.shutdown(); err != nil {
+		return err
 	}
+
+	return <-served
+}
+
+// start runs the OnStart hooks within the startup timeout, if one is set.
+func (a *App) start(ctx context.Context) error {
+	if a.cfg.startupTimeout <= 0 {
+		return a.runStartHooks(ctx)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, a.cfg.startupTimeout-time.Since(a.startedAt))
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- a.runStartHooks(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		// The hooks see the cancelled context; wait for them to stop and
+		// clean up so nothing runs alongside the shutdown hooks.
+		<-done
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return ErrStartupTimeout
+		}
+		return ctx.Err()
+	}
+}
+
+func (a *App) shutdown() error {
+	wasRunning := a.State() == Running
+	a.setState(Draining)
+	if wasRunning && a.cfg.preShutdownDelay > 0 {
+		a.cfg.logger.Info("Draining before shutdown", "delay", a.cfg.preShutdownDelay)
+		started := time.Now()
+		time.Sleep(a.cfg.preShutdownDelay)
+		a.recordPhase(&a.report.Delay, started)
+	}
+	a.cfg.logger.Info("Trying to shut down gracefully")
+	a.startDrain()
+	a.cancel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.cfg.shutdownTimeout)
+	defer cancel()
+
+	progress, stopProgress := context.WithCancel(ctx)
+	go a.logShutdownProgress(progress)
+
+	started := time.Now()
+	servers := a.stopServers(ctx)
+	err := a.cfg.app.ShutdownWithContext(ctx)
+	stopProgress()
+	if errors.Is(err, context.DeadlineExceeded) {
+		a.cfg.logger.Error("Exit by shut down timeout", "requests", a.InFlight())
+		err = a.hardClose()
+	} else {
+		a.cfg.logger.Info("Server stopped", "requests", a.InFlight())
+	}
+	// Serve may not have registered the listener yet; closing it here makes
+	// sure it returns either way.
+	_ = a.ln.Close()
+	serr := <-servers
+	a.recordPhase(&a.report.Drain, started)
+
+	started = time.Now()
+	werr := a.waitWorkers(ctx)
+	a.recordPhase(&a.report.Workers, started)
+
+	started = time.Now()
+	herr := a.runShutdownHooks()
+	a.recordPhase(&a.report.Hooks, started)
+
+	return errors.Join(err, serr, werr, herr)
+}
+
+// RunWithListener is Run on a listener the caller created, e.g. with custom
+// socket options or an in-memory listener in tests. Configured TLS still
+// wraps it.
+func (a *App) RunWithListener(ln net.Listener) error {
+	a.custom = ln
+	return a.Run()
+}
+
+func (a *App) listen(ctx context.Context) (net.Listener, error) {
+	var err error
+	ln := a.custom
+	if ln == nil {
+		ln, err = inheritedListener()
+	}
+	if err == nil && ln == nil {
+		ln, err = a.bind(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+	a.tcp = ln
+
+	if a.cfg.tlsEnabled() {
+		return a.tlsListener(ctx, ln)
+	}
+
+	return ln, nil
+}
+
+func (a *App) wrapHandler() {
+	srv := a.cfg.app.Server()
+	a.applyServerHeader(srv)
+	a.trackConnections(srv)
+	srv.Handler = a.handler(srv.Handler)
+}
+
+func (a *App) handler(next fasthttp.RequestHandler) fasthttp.RequestHandler {
+	if a.cfg.recover {
+		next = a.recoverHandler(next)
+	}
+	if a.cfg.livenessPath != "" || a.cfg.readinessPath != "" || a.cfg.startupPath != "" {
+		next = a.probesHandler(next)
+	}
+	if len(a.healthChecks) > 0 || a.cfg.healthPath != "" {
+		next = a.healthHandler(next)
+	}
+	if a.cfg.drainRejection != nil {
+		next = a.drainRejectionHandler(next)
+	}
+	if a.cfg.mutualTLS() {
+		next = clientCertHandler(next)
+	}
+	if a.cfg.hsts != nil {
+		next = hstsHandler(*a.cfg.hsts, next)
+	}
+	if a.cfg.securityHeaders {
+		next = securityHeadersHandler(next)
+	}
+	if a.cfg.serverHeader != nil || len(a.cfg.stripHeaders) > 0 {
+		next = headersHandler(a.cfg.serverHeader, a.cfg.stripHeaders, next)
+	}
+	if a.cfg.drainConnClose {
+		next = a.drainConnCloseHandler(next)
+	}
+	if a.cfg.slowRequest > 0 {
+		next = a.slowRequestHandler(next)
+	}
+	if a.cfg.accessLog != nil {
+		next = a.accessLogHandler(next)
+	}
+	if a.cfg.requestContext != nil {
+		next = a.requestContextHandler(next)
+	}
+	if a.cfg.requestID {
+		next = requestIDHandler(next)
+	}
+	next = a.inflightHandler(next)
+
+	return next
+}
+
+func (a *App) Shutdown(ctx context.Context) error {
+	a.stop()
+
+	select {
+	case <-a.done:
+		return a.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Start runs the app in the background without handling signals and returns
+// once it is Running, or with the startup error. Use Stop to shut it down.
+func (a *App) Start() error {
+	a.cfg.signals = []os.Signal{}
+	go func() {
+		_ = a.Run()
+	}()
+
+	select {
+	case <-a.running:
+		return nil
+	case <-a.done:
+		return a.err
+	}
+}
+
+func (a *App) Stop(ctx context.Context) error {
+	return a.Shutdown(ctx)
+}
+
+func (a *App) ShutdownWithTimeout(timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	return a.Shutdown(ctx)
+}
+
+func (a *App) stop() {
+	a.stopWith(CauseShutdown, nil)
+}
+
+func (a *App) stopWith(cause ShutdownCause, err error) {
+	a.setCause(cause, nil, err)
+	a.quitOnce.Do(func() {
+		close(a.quit)
+	})
 }
@@ -0,0 +1,156 @@
+package sgsr
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func newTestApp(t *testing.T, configure func(Config) Config) (*App, *fiber.App) {
+	t.Helper()
+
+	fa := fiber.New(fiber.Config{DisableStartupMessage: true})
+	cfg := NewConfig(slog.New(slog.NewTextHandler(io.Discard, nil)), fa, "127.0.0.1:0")
+	if configure != nil {
+		cfg = configure(cfg)
+	}
+	return NewApp(cfg), fa
+}
+
+func stopApp(t *testing.T, a *App) error {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return a.Stop(ctx)
+}
+
+// flakyListener fails its first Accept with a permanent error.
+type flakyListener struct {
+	net.Listener
+	failed atomic.Bool
+}
+
+func (l *flakyListener) Accept() (net.Conn, error) {
+	if l.failed.CompareAndSwap(false, true) {
+		return nil, errors.New("transient accept failure")
+	}
+	return l.Listener.Accept()
+}
+
+// triggeredListener fails one Accept for every value sent on fail.
+type triggeredListener struct {
+	net.Listener
+	fail chan struct{}
+}
+
+func (l *triggeredListener) Accept() (net.Conn, error) {
+	select {
+	case <-l.fail:
+		return nil, errors.New("triggered accept failure")
+	default:
+	}
+	return l.Listener.Accept()
+}
+
+func TestRestartAttemptsResetAfterRecovery(t *testing.T) {
+	a, fa := newTestApp(t, func(c Config) Config {
+		return c.WithRestartPolicy(RestartPolicy{
+			MaxAttempts:    1,
+			InitialBackoff: 10 * time.Millisecond,
+			MaxBackoff:     50 * time.Millisecond,
+		})
+	})
+	fa.Get("/", func(c *fiber.Ctx) error {
+		return c.SendString("ok")
+	})
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tl := &triggeredListener{Listener: ln, fail: make(chan struct{}, 1)}
+	a.custom = tl
+	if err := a.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	client := &http.Client{Timeout: time.Second, Transport: &http.Transport{DisableKeepAlives: true}}
+	url := "http://" + a.Addr().String() + "/"
+	// Each failure follows a stable period, so each is a first attempt.
+	for i := range 3 {
+		time.Sleep(100 * time.Millisecond)
+		select {
+		case tl.fail <- struct{}{}:
+		case <-a.Done():
+			t.Fatalf("app stopped: %+v", a.ShutdownReport())
+		}
+		// The pending Accept only sees the failure on the next connection.
+		if resp, err := client.Get(url); err == nil {
+			resp.Body.Close()
+		}
+		deadline := time.Now().Add(5 * time.Second)
+		for {
+			resp, err := client.Get(url)
+			if err == nil {
+				resp.Body.Close()
+				break
+			}
+			if time.Now().After(deadline) || a.State() == Stopped {
+				t.Fatalf("app did not serve after failure %d: %v", i+1, err)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	if err := stopApp(t, a); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRestartThenShutdownReportsShutdown(t *testing.T) {
+	a, fa := newTestApp(t, func(c Config) Config {
+		return c.WithRestartPolicy(RestartPolicy{MaxAttempts: 1, InitialBackoff: 10 * time.Millisecond})
+	})
+	fa.Get("/", func(c *fiber.Ctx) error {
+		return c.SendString("ok")
+	})
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	a.custom = &flakyListener{Listener: ln}
+	if err := a.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	url := "http://" + a.Addr().String() + "/"
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		resp, err := http.Get(url)
+		if err == nil {
+			resp.Body.Close()
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("app did not serve after restart: %v", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if err := stopApp(t, a); err != nil {
+		t.Fatal(err)
+	}
+	if r := a.ShutdownReport(); r.Cause != CauseShutdown || r.Err != nil {
+		t.Fatalf("report = %+v, want cause %q without error", r, CauseShutdown)
+	}
+}
@@ -0,0 +1,126 @@
+package sgsr
+
+import (
+	"bufio"
+	"encoding/json"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/valyala/fasthttp"
+)
+
+const (
+	// WSCloseGoingAway is the WebSocket close code for a server shutting down.
+	WSCloseGoingAway = 1001
+	// SSEGoingAway is the SSE comment sent to streaming clients at drain start.
+	SSEGoingAway = ": server going away\n\n"
+)
+
+type drain struct {
+	once  sync.Once
+	ch    chan struct{}
+	mu    sync.Mutex
+	funcs []func()
+}
+
+// Draining is closed when graceful shutdown starts closing connections, so
+// long-lived handlers (SSE, WebSocket) can say goodbye before the hard close.
+func (a *App) Draining() <-chan struct{} {
+	return a.drain.ch
+}
+
+// OnDrain registers fn to be called when draining starts.
+func (a *App) OnDrain(fn func()) {
+	a.drain.mu.Lock()
+	defer a.drain.mu.Unlock()
+
+	a.drain.funcs = append(a.drain.funcs, fn)
+}
+
+// WriteSSEGoingAway writes SSEGoingAway to an SSE stream and flushes it.
+func WriteSSEGoingAway(w *bufio.Writer) error {
+	if _, err := w.WriteString(SSEGoingAway); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+func (a *App) startDrain() {
+	a.drain.once.Do(func() {
+		close(a.drain.ch)
+
+		a.drain.mu.Lock()
+		funcs := a.drain.funcs
+		a.drain.mu.Unlock()
+
+		for _, fn := range funcs {
+			fn()
+		}
+	})
+}
+
+// WithDrainConnectionClose answers with Connection: close once the app is
+// Draining, including the pre-shutdown delay, so persistent connections are
+// given up instead of lingering until the hard close.
+func (c Config) WithDrainConnectionClose() Config {
+	c.drainConnClose = true
+	return c
+}
+
+func (a *App) drainConnCloseHandler(next fasthttp.RequestHandler) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		next(ctx)
+
+		if a.State() == Draining {
+			ctx.SetConnectionClose()
+		}
+	}
+}
+
+// DrainRejection configures WithDrainRejection.
+type DrainRejection struct {
+	RetryAfter time.Duration
+	// ExemptProbes keeps answering the probe and health endpoints.
+	ExemptProbes bool
+}
+
+// WithDrainRejection answers requests arriving after draining started with
+// 503 and a Retry-After header instead of serving them during the shutdown
+// window.
+func (c Config) WithDrainRejection(r DrainRejection) Config {
+	c.drainRejection = &r
+	return c
+}
+
+func (a *App) drainRejectionHandler(next fasthttp.RequestHandler) fasthttp.RequestHandler {
+	r := *a.cfg.drainRejection
+	retryAfter := strconv.FormatInt(int64((r.RetryAfter+time.Second-1)/time.Second), 10)
+	body, _ := json.Marshal(NewProblem(fasthttp.StatusServiceUnavailable, "server is shutting down"))
+	exempt := map[string]bool{}
+	if r.ExemptProbes {
+		for _, p := range []string{a.cfg.livenessPath, a.cfg.readinessPath, a.cfg.startupPath} {
+			exempt[p] = p != ""
+		}
+		if len(a.healthChecks) > 0 || a.cfg.healthPath != "" {
+			exempt[a.healthPath()] = true
+		}
+	}
+	return func(ctx *fasthttp.RequestCtx) {
+		select {
+		case <-a.drain.ch:
+		default:
+			next(ctx)
+			return
+		}
+		if exempt[string(ctx.Path())] {
+			next(ctx)
+			return
+		}
+
+		ctx.Response.Header.Set(fasthttp.HeaderRetryAfter, retryAfter)
+		ctx.SetStatusCode(fasthttp.StatusServiceUnavailable)
+		ctx.SetContentType(MIMEApplicationProblemJSON)
+		ctx.SetBody(body)
+	}
+}
@@ -0,0 +1,35 @@
+package sgsr
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrStartup wraps every error that prevented the app from reaching Running.
+	ErrStartup = errors.New("sgsr: startup failed")
+	// ErrStartupTimeout is wrapped by ErrStartup when the startup timeout
+	// elapsed before the app was Running.
+	ErrStartupTimeout = errors.New("sgsr: startup timed out")
+	// ErrServe wraps listener errors returned after a successful startup.
+	ErrServe = errors.New("sgsr: serve failed")
+	// ErrShutdownTimeout is returned when connections did not drain within
+	// the shutdown timeout.
+	ErrShutdownTimeout = errors.New("sgsr: shutdown timed out")
+)
+
+// HookError reports a failing lifecycle hook or worker. Phase is "start",
+// "shutdown", "reload" or "worker".
+type HookError struct {
+	Phase string
+	Name  string
+	Err   error
+}
+
+func (e *HookError) Error() string {
+	return fmt.Sprintf("%s hook %s: %v", e.Phase, e.Name, e.Err)
+}
+
+func (e *HookError) Unwrap() error {
+	return e.Err
+}
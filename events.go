@@ -0,0 +1,92 @@
+package sgsr
+
+import (
+	"net"
+	"sync"
+	"time"
+)
+
+type EventType string
+
+const (
+	EventStarting      EventType = "starting"
+	EventListening     EventType = "listening"
+	EventRunning       EventType = "running"
+	EventDraining      EventType = "draining"
+	EventHookCompleted EventType = "hook-completed"
+	EventHookFailed    EventType = "hook-failed"
+	EventStopped       EventType = "stopped"
+)
+
+// Event is a lifecycle transition. Phase and Hook are set for hook events,
+// Addr for EventListening and Err for failures and EventStopped.
+type Event struct {
+	Type     EventType
+	Time     time.Time
+	Phase    string
+	Hook     string
+	Duration time.Duration
+	Addr     net.Addr
+	Err      error
+}
+
+type events struct {
+	mu    sync.RWMutex
+	funcs []func(Event)
+	chans []chan Event
+}
+
+// OnEvent calls fn synchronously for every lifecycle event; fn must not block.
+func (a *App) OnEvent(fn func(Event)) {
+	a.events.mu.Lock()
+	defer a.events.mu.Unlock()
+
+	a.events.funcs = append(a.events.funcs, fn)
+}
+
+// Events returns a channel receiving lifecycle events, closed after
+// EventStopped. Events are dropped when the buffer is full.
+func (a *App) Events(buffer int) <-chan Event {
+	ch := make(chan Event, buffer)
+
+	a.events.mu.Lock()
+	defer a.events.mu.Unlock()
+
+	a.events.chans = append(a.events.chans, ch)
+	return ch
+}
+
+func (a *App) emit(e Event) {
+	e.Time = time.Now()
+
+	a.events.mu.RLock()
+	defer a.events.mu.RUnlock()
+
+	for _, fn := range a.events.funcs {
+		fn(e)
+	}
+	for _, ch := range a.events.chans {
+		select {
+		case ch <- e:
+		default:
+		}
+	}
+}
+
+func (a *App) closeEvents() {
+	a.events.mu.Lock()
+	defer a.events.mu.Unlock()
+
+	for _, ch := range a.events.chans {
+		close(ch)
+	}
+	a.events.chans = nil
+}
+
+func (a *App) emitHook(phase, name string, d time.Duration, err error) {
+	e := Event{Type: EventHookCompleted, Phase: phase, Hook: name, Duration: d, Err: err}
+	if err != nil {
+		e.Type = EventHookFailed
+	}
+	a.emit(e)
+}
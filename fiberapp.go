@@ -0,0 +1,64 @@
+package sgsr
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// FiberOptions configures NewFiberApp. Zero values take the hardened
+// defaults below rather than fiber's.
+type FiberOptions struct {
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+	IdleTimeout  time.Duration
+	BodyLimit    int
+
+	// ProxyHeader (e.g. X-Forwarded-For) is only trusted from TrustedProxies;
+	// with no trusted proxies it is ignored.
+	ProxyHeader    string
+	TrustedProxies []string
+
+	// ErrorHandler defaults to ProblemErrorHandler.
+	ErrorHandler fiber.ErrorHandler
+}
+
+const (
+	defaultReadTimeout  = 10 * time.Second
+	defaultWriteTimeout = 30 * time.Second
+	defaultIdleTimeout  = 2 * time.Minute
+	defaultBodyLimit    = 1 << 20
+)
+
+// NewFiberApp returns a fiber.App with timeouts, a 1 MiB body limit, no
+// startup banner and proxy headers honoured only from trusted proxies.
+func NewFiberApp(opts FiberOptions) *fiber.App {
+	cfg := fiber.Config{
+		ReadTimeout:           orDuration(opts.ReadTimeout, defaultReadTimeout),
+		WriteTimeout:          orDuration(opts.WriteTimeout, defaultWriteTimeout),
+		IdleTimeout:           orDuration(opts.IdleTimeout, defaultIdleTimeout),
+		BodyLimit:             defaultBodyLimit,
+		DisableStartupMessage: true,
+		ErrorHandler:          opts.ErrorHandler,
+	}
+	if opts.BodyLimit > 0 {
+		cfg.BodyLimit = opts.BodyLimit
+	}
+	if cfg.ErrorHandler == nil {
+		cfg.ErrorHandler = ProblemErrorHandler
+	}
+	if opts.ProxyHeader != "" && len(opts.TrustedProxies) > 0 {
+		cfg.ProxyHeader = opts.ProxyHeader
+		cfg.EnableTrustedProxyCheck = true
+		cfg.TrustedProxies = opts.TrustedProxies
+	}
+
+	return fiber.New(cfg)
+}
+
+func orDuration(d, def time.Duration) time.Duration {
+	if d > 0 {
+		return d
+	}
+	return def
+}
@@ -0,0 +1,89 @@
+package sgsr
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// Group runs several apps under one lifecycle: they start together and all of
+// them shut down on the first signal or as soon as any one of them stops.
+type Group struct {
+	apps    []*App
+	signals []os.Signal
+}
+
+func NewGroup(apps ...*App) *Group {
+	for _, a := range apps {
+		a.cfg.signals = []os.Signal{}
+	}
+
+	return &Group{
+		apps:    apps,
+		signals: []os.Signal{syscall.SIGINT, syscall.SIGTERM},
+	}
+}
+
+func (g *Group) WithSignals(sigs ...os.Signal) *Group {
+	g.signals = sigs
+	return g
+}
+
+func (g *Group) Run() error {
+	// A nil channel never delivers, so without signals only an app stopping
+	// ends the group.
+	var sigs chan os.Signal
+	if len(g.signals) > 0 {
+		sigs = make(chan os.Signal, 1)
+		signal.Notify(sigs, g.signals...)
+		defer signal.Stop(sigs)
+	}
+
+	errs := make(chan error, len(g.apps))
+	for _, a := range g.apps {
+		go func() {
+			errs <- a.Run()
+		}()
+	}
+
+	var all []error
+	select {
+	case sig := <-sigs:
+		for _, a := range g.apps {
+			a.setCause(CauseSignal, sig, nil)
+		}
+	case err := <-errs:
+		all = append(all, err)
+	}
+
+	for _, a := range g.apps {
+		a.stop()
+	}
+	for len(all) < len(g.apps) {
+		all = append(all, <-errs)
+	}
+
+	return errors.Join(all...)
+}
+
+func (g *Group) Shutdown(ctx context.Context) error {
+	for _, a := range g.apps {
+		a.stop()
+	}
+
+	var errs []error
+	for _, a := range g.apps {
+		errs = append(errs, a.Shutdown(ctx))
+	}
+	return errors.Join(errs...)
+}
+
+func (g *Group) ShutdownWithTimeout(timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	return g.Shutdown(ctx)
+}
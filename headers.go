@@ -0,0 +1,70 @@
+package sgsr
+
+import (
+	"slices"
+
+	"github.com/valyala/fasthttp"
+)
+
+// WithServerHeader sets the Server response header; an empty name removes it.
+func (c Config) WithServerHeader(name string) Config {
+	c.serverHeader = &name
+	return c
+}
+
+// WithSecurityHeaders sets X-Content-Type-Options, X-Frame-Options and
+// Referrer-Policy on responses that don't set them already.
+func (c Config) WithSecurityHeaders() Config {
+	c.securityHeaders = true
+	return c
+}
+
+func (c Config) WithStripHeaders(names ...string) Config {
+	c.stripHeaders = append(slices.Clip(c.stripHeaders), names...)
+	return c
+}
+
+func (a *App) applyServerHeader(srv *fasthttp.Server) {
+	if a.cfg.serverHeader == nil {
+		return
+	}
+
+	srv.Name = *a.cfg.serverHeader
+	srv.NoDefaultServerHeader = srv.Name == ""
+}
+
+func headersHandler(server *string, strip []string, next fasthttp.RequestHandler) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		next(ctx)
+
+		for _, name := range strip {
+			ctx.Response.Header.Del(name)
+		}
+		if server == nil {
+			return
+		}
+		if *server == "" {
+			ctx.Response.Header.Del(fasthttp.HeaderServer)
+		} else {
+			ctx.Response.Header.SetServer(*server)
+		}
+	}
+}
+
+var securityHeaders = [...][2]string{
+	{fasthttp.HeaderXContentTypeOptions, "nosniff"},
+	{fasthttp.HeaderXFrameOptions, "DENY"},
+	{fasthttp.HeaderReferrerPolicy, "strict-origin-when-cross-origin"},
+}
+
+func securityHeadersHandler(next fasthttp.RequestHandler) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		next(ctx)
+
+		for _, h := range securityHeaders {
+			if len(ctx.Response.Header.Peek(h[0])) == 0 {
+				ctx.Response.Header.Set(h[0], h[1])
+			}
+		}
+	}
+}
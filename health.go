@@ -0,0 +1,173 @@
+package sgsr
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+)
+
+const (
+	DefaultHealthPath = "/healthz"
+
+	defaultHealthTimeout = 5 * time.Second
+)
+
+type HealthChecker interface {
+	Check(ctx context.Context) error
+}
+
+type HealthCheckerFunc func(ctx context.Context) error
+
+func (f HealthCheckerFunc) Check(ctx context.Context) error {
+	return f(ctx)
+}
+
+type HealthCheckOptions struct {
+	// Timeout bounds a single check run, 5s when zero.
+	Timeout time.Duration
+	// TTL caches the last result; zero runs the check on every probe.
+	TTL time.Duration
+}
+
+type HealthResult struct {
+	Status  string        `json:"status"`
+	Latency time.Duration `json:"latency_ns"`
+	Error   string        `json:"error,omitempty"`
+	Cached  bool          `json:"cached,omitempty"`
+}
+
+type HealthReport struct {
+	Status  string                  `json:"status"`
+	Checks  map[string]HealthResult `json:"checks"`
+	Runtime *RuntimeStats           `json:"runtime,omitempty"`
+}
+
+type healthCheck struct {
+	name    string
+	checker HealthChecker
+	opts    HealthCheckOptions
+
+	mu      sync.Mutex
+	last    HealthResult
+	checked time.Time
+}
+
+// WithHealthPath overrides DefaultHealthPath for the aggregated health endpoint.
+func (c Config) WithHealthPath(path string) Config {
+	c.healthPath = path
+	return c
+}
+
+// RegisterHealthCheck adds a named check to the health endpoint, which is
+// served when at least one check is registered before Run.
+func (a *App) RegisterHealthCheck(name string, checker HealthChecker, opts ...HealthCheckOptions) {
+	hc := &healthCheck{name: name, checker: checker}
+	if len(opts) > 0 {
+		hc.opts = opts[0]
+	}
+	if hc.opts.Timeout <= 0 {
+		hc.opts.Timeout = defaultHealthTimeout
+	}
+
+	a.healthMu.Lock()
+	a.healthChecks = append(a.healthChecks, hc)
+	a.healthMu.Unlock()
+}
+
+func (hc *healthCheck) run(ctx context.Context) HealthResult {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+
+	if hc.opts.TTL > 0 && !hc.checked.IsZero() && time.Since(hc.checked) < hc.opts.TTL {
+		res := hc.last
+		res.Cached = true
+		return res
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, hc.opts.Timeout)
+	defer cancel()
+
+	started := time.Now()
+	res := HealthResult{Status: "ok"}
+	if err := hc.checker.Check(ctx); err != nil {
+		res.Status = "fail"
+		res.Error = err.Error()
+	}
+	res.Latency = time.Since(started)
+
+	hc.last = res
+	hc.checked = time.Now()
+	return res
+}
+
+func (a *App) Health(ctx context.Context) HealthReport {
+	a.healthMu.RLock()
+	checks := append([]*healthCheck(nil), a.healthChecks...)
+	a.healthMu.RUnlock()
+
+	report := HealthReport{Status: "ok", Checks: make(map[string]HealthResult, len(checks))}
+	results := make([]HealthResult, len(checks))
+
+	var wg sync.WaitGroup
+	for i, hc := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = hc.run(ctx)
+		}()
+	}
+	wg.Wait()
+
+	for i, hc := range checks {
+		report.Checks[hc.name] = results[i]
+		if results[i].Status != "ok" {
+			report.Status = "fail"
+		}
+	}
+
+	return report
+}
+
+func (a *App) healthPath() string {
+	if a.cfg.healthPath != "" {
+		return a.cfg.healthPath
+	}
+	return DefaultHealthPath
+}
+
+func (a *App) healthHandler(next fasthttp.RequestHandler) fasthttp.RequestHandler {
+	path := a.healthPath()
+	return func(ctx *fasthttp.RequestCtx) {
+		if string(ctx.Path()) != path {
+			next(ctx)
+			return
+		}
+
+		report := a.Health(ctx)
+		if a.cfg.verboseHealth {
+			stats := a.RuntimeStats()
+			report.Runtime = &stats
+		}
+		writeJSON(ctx, report.Status == "ok", report)
+	}
+}
+
+func writeJSON(ctx *fasthttp.RequestCtx, ok bool, v any) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		ctx.Error(err.Error(), fasthttp.StatusInternalServerError)
+		return
+	}
+
+	status := fasthttp.StatusOK
+	if !ok {
+		status = fasthttp.StatusServiceUnavailable
+	}
+	ctx.SetStatusCode(status)
+	ctx.SetContentType(fiber.MIMEApplicationJSON)
+	ctx.SetBody(body)
+}
@@ -0,0 +1,87 @@
+package sgsr
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	"github.com/valyala/fasthttp"
+)
+
+const healthcheckTimeout = 5 * time.Second
+
+var errHealthcheckMTLS = errors.New("sgsr: healthcheck has no client certificate for an app requiring one")
+
+// HealthcheckMain probes the app described by c from inside its own
+// container and exits with 0 when it answers 2xx and 1 otherwise, so images
+// without curl can define a HEALTHCHECK:
+//
+//	if len(os.Args) > 1 && os.Args[1] == "healthcheck" {
+//		sgsr.HealthcheckMain(cfg)
+//	}
+//
+// It uses the health path, falling back to the readiness and liveness probes
+// and then DefaultHealthPath, served once health checks are registered. Apps
+// requiring client certificates (WithClientCAs, WithClientVerifier) cannot be
+// probed this way and always fail.
+func HealthcheckMain(c Config) {
+	if err := Healthcheck(c); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+// Healthcheck is HealthcheckMain without exiting.
+func Healthcheck(c Config) error {
+	url, err := c.healthcheckURL()
+	if err != nil {
+		return err
+	}
+
+	req := fasthttp.AcquireRequest()
+	defer fasthttp.ReleaseRequest(req)
+	resp := fasthttp.AcquireResponse()
+	defer fasthttp.ReleaseResponse(resp)
+	req.SetRequestURI(url)
+
+	// The probe targets this process over loopback, where the serving
+	// certificate rarely matches the host name.
+	client := &fasthttp.Client{TLSConfig: &tls.Config{InsecureSkipVerify: true}}
+	if err := client.DoTimeout(req, resp, healthcheckTimeout); err != nil {
+		return err
+	}
+	if code := resp.StatusCode(); code < 200 || code > 299 {
+		return fmt.Errorf("sgsr: %s answered %d", url, code)
+	}
+	return nil
+}
+
+func (c Config) healthcheckURL() (string, error) {
+	if c.mutualTLS() {
+		return "", errHealthcheckMTLS
+	}
+
+	path := c.healthPath
+	for _, p := range []string{c.readinessPath, c.livenessPath, DefaultHealthPath} {
+		if path == "" {
+			path = p
+		}
+	}
+
+	host, port, err := net.SplitHostPort(c.addr)
+	if err != nil {
+		return "", err
+	}
+	if ip := net.ParseIP(host); host == "" || ip != nil && ip.IsUnspecified() {
+		host = "127.0.0.1"
+	}
+	scheme := "http"
+	if c.tlsEnabled() {
+		scheme = "https"
+	}
+	return scheme + "://" + net.JoinHostPort(host, port) + path, nil
+}
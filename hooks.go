@@ -0,0 +1,119 @@
+package sgsr
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strconv"
+	"time"
+)
+
+const defaultHookTimeout = 10 * time.Second
+
+type startHook struct {
+	start   func(ctx context.Context) error
+	cleanup []func(ctx context.Context) error
+}
+
+// OnStart registers fn to run once the listener is up. If a later start hook
+// fails, the cleanup funcs of every hook that already ran are called in
+// reverse order.
+func (a *App) OnStart(fn func(ctx context.Context) error, cleanup ...func(ctx context.Context) error) {
+	a.startHooks = append(a.startHooks, startHook{start: fn, cleanup: cleanup})
+}
+
+type shutdownHook struct {
+	name    string
+	fn      func(ctx context.Context) error
+	timeout time.Duration
+}
+
+// OnShutdown registers fn to run during graceful shutdown after the server
+// stops accepting requests. Hooks run in reverse registration order, each
+// bounded by its own timeout (10s unless given); a hook that outlives it is
+// abandoned and reported.
+func (a *App) OnShutdown(name string, fn func(ctx context.Context) error, timeout ...time.Duration) {
+	h := shutdownHook{name: name, fn: fn, timeout: defaultHookTimeout}
+	if len(timeout) > 0 {
+		h.timeout = timeout[0]
+	}
+	a.shutdownHooks = append(a.shutdownHooks, h)
+}
+
+// Manage closes c during graceful shutdown. Managed closers share the
+// OnShutdown ordering, so they are closed in reverse registration order; a
+// Close that outlives its timeout is abandoned and reported.
+func (a *App) Manage(name string, c io.Closer, timeout ...time.Duration) {
+	a.OnShutdown(name, func(context.Context) error {
+		return c.Close()
+	}, timeout...)
+}
+
+func (a *App) runStartHooks(ctx context.Context) error {
+	for i, h := range a.startHooks {
+		if err := ctx.Err(); err != nil {
+			a.cleanupStartHooks(ctx, a.startHooks[:i])
+			return err
+		}
+		name := strconv.Itoa(i)
+		started := time.Now()
+		err := h.start(ctx)
+		a.emitHook("start", name, time.Since(started), err)
+		if err != nil {
+			a.cleanupStartHooks(ctx, a.startHooks[:i])
+			return &HookError{Phase: "start", Name: name, Err: err}
+		}
+	}
+
+	return nil
+}
+
+func (a *App) cleanupStartHooks(ctx context.Context, done []startHook) {
+	ctx = context.WithoutCancel(ctx)
+	for i := len(done) - 1; i >= 0; i-- {
+		for _, fn := range done[i].cleanup {
+			if err := fn(ctx); err != nil {
+				a.cfg.logger.Error("Start hook cleanup failed", "hook", i, "error", err)
+			}
+		}
+	}
+}
+
+func (a *App) runShutdownHooks() error {
+	var errs []error
+	for i := len(a.shutdownHooks) - 1; i >= 0; i-- {
+		h := a.shutdownHooks[i]
+		started := time.Now()
+		err := runHook(h)
+
+		if err != nil {
+			a.cfg.logger.Error("Shutdown hook failed", "hook", h.name, "duration", time.Since(started), "error", err)
+			a.emitHook("shutdown", h.name, time.Since(started), err)
+			errs = append(errs, &HookError{Phase: "shutdown", Name: h.name, Err: err})
+			continue
+		}
+		a.cfg.logger.Info("Shutdown hook completed", "hook", h.name, "duration", time.Since(started))
+		a.emitHook("shutdown", h.name, time.Since(started), nil)
+	}
+
+	return errors.Join(errs...)
+}
+
+// runHook abandons a hook that outlives its timeout, whether or not it
+// honours its context.
+func runHook(h shutdownHook) error {
+	ctx, cancel := context.WithTimeout(context.Background(), h.timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- h.fn(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,35 @@
+package sgsr
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+)
+
+func TestStartHookFailureRunsCleanup(t *testing.T) {
+	a, _ := newTestApp(t, nil)
+	hookErr := errors.New("migration failed")
+	var cleaned atomic.Bool
+	a.OnStart(func(context.Context) error {
+		return nil
+	}, func(context.Context) error {
+		cleaned.Store(true)
+		return nil
+	})
+	a.OnStart(func(context.Context) error {
+		return hookErr
+	})
+
+	err := a.Start()
+	var he *HookError
+	if !errors.Is(err, ErrStartup) || !errors.Is(err, hookErr) || !errors.As(err, &he) || he.Phase != "start" {
+		t.Fatalf("Start() = %v, want start HookError wrapped in ErrStartup", err)
+	}
+	if !cleaned.Load() {
+		t.Fatal("cleanup of the first hook did not run")
+	}
+	if r := a.ShutdownReport(); r.Cause != CauseStartup {
+		t.Fatalf("report cause = %q, want %q", r.Cause, CauseStartup)
+	}
+}
@@ -0,0 +1,47 @@
+package sgsr
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/valyala/fasthttp"
+)
+
+type HSTS struct {
+	MaxAge            time.Duration
+	IncludeSubDomains bool
+	Preload           bool
+	// TrustProxy also emits the header when X-Forwarded-Proto is https.
+	TrustProxy bool
+}
+
+func (c Config) WithHSTS(h HSTS) Config {
+	c.hsts = &h
+	return c
+}
+
+func (h HSTS) value() string {
+	var b strings.Builder
+	b.WriteString("max-age=")
+	b.WriteString(strconv.FormatInt(int64(h.MaxAge/time.Second), 10))
+	if h.IncludeSubDomains {
+		b.WriteString("; includeSubDomains")
+	}
+	if h.Preload {
+		b.WriteString("; preload")
+	}
+	return b.String()
+}
+
+func hstsHandler(h HSTS, next fasthttp.RequestHandler) fasthttp.RequestHandler {
+	value := h.value()
+	return func(ctx *fasthttp.RequestCtx) {
+		next(ctx)
+		secure := ctx.IsTLS() ||
+			h.TrustProxy && strings.EqualFold(string(ctx.Request.Header.Peek(fasthttp.HeaderXForwardedProto)), "https")
+		if secure {
+			ctx.Response.Header.Set(fasthttp.HeaderStrictTransportSecurity, value)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package sgsr
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/valyala/fasthttp"
+)
+
+const shutdownProgressInterval = 2 * time.Second
+
+type inflight struct {
+	requests    atomic.Int64
+	connections atomic.Int64
+
+	mu     sync.Mutex
+	active map[uint64]AbortedRequest
+	conns  map[net.Conn]struct{}
+}
+
+// AbortedRequest describes a request that was still running when the
+// shutdown timeout forced its connection closed.
+type AbortedRequest struct {
+	Method   string
+	Path     string
+	Client   string
+	Duration time.Duration
+
+	started time.Time
+}
+
+// ShutdownTimeoutError is returned by Run when requests were still running
+// at the end of the grace period. It matches ErrShutdownTimeout.
+type ShutdownTimeoutError struct {
+	Aborted []AbortedRequest
+}
+
+func (e *ShutdownTimeoutError) Error() string {
+	return fmt.Sprintf("%s: %d requests aborted", ErrShutdownTimeout, len(e.Aborted))
+}
+
+func (e *ShutdownTimeoutError) Is(target error) bool {
+	return target == ErrShutdownTimeout
+}
+
+// InFlight returns the number of requests currently being handled.
+func (a *App) InFlight() int {
+	return int(a.inflight.requests.Load())
+}
+
+// OpenConnections returns the number of connections currently open.
+func (a *App) OpenConnections() int {
+	return int(a.inflight.connections.Load())
+}
+
+func (a *App) trackConnections(srv *fasthttp.Server) {
+	a.inflight.active = make(map[uint64]AbortedRequest)
+	a.inflight.conns = make(map[net.Conn]struct{})
+
+	prev := srv.ConnState
+	srv.ConnState = func(c net.Conn, state fasthttp.ConnState) {
+		switch state {
+		case fasthttp.StateNew:
+			a.inflight.connections.Add(1)
+			a.inflight.mu.Lock()
+			a.inflight.conns[c] = struct{}{}
+			a.inflight.mu.Unlock()
+		case fasthttp.StateClosed, fasthttp.StateHijacked:
+			a.inflight.connections.Add(-1)
+			a.inflight.mu.Lock()
+			delete(a.inflight.conns, c)
+			a.inflight.mu.Unlock()
+		}
+		if prev != nil {
+			prev(c, state)
+		}
+	}
+}
+
+func (a *App) inflightHandler(next fasthttp.RequestHandler) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		a.inflight.requests.Add(1)
+		id := ctx.ID()
+		a.inflight.mu.Lock()
+		a.inflight.active[id] = AbortedRequest{
+			Method:  string(ctx.Method()),
+			Path:    string(ctx.Path()),
+			Client:  ctx.RemoteAddr().String(),
+			started: ctx.Time(),
+		}
+		a.inflight.mu.Unlock()
+
+		defer func() {
+			a.inflight.mu.Lock()
+			delete(a.inflight.active, id)
+			a.inflight.mu.Unlock()
+			a.inflight.requests.Add(-1)
+		}()
+		next(ctx)
+	}
+}
+
+// hardClose closes every connection left after the grace period and returns
+// the requests that were cut off.
+func (a *App) hardClose() *ShutdownTimeoutError {
+	a.inflight.mu.Lock()
+	defer a.inflight.mu.Unlock()
+
+	aborted := make([]AbortedRequest, 0, len(a.inflight.active))
+	for _, r := range a.inflight.active {
+		r.Duration = time.Since(r.started)
+		aborted = append(aborted, r)
+		a.cfg.logger.Warn("Aborting request", "method", r.Method, "path", r.Path, "client", r.Client, "duration", r.Duration)
+	}
+	for c := range a.inflight.conns {
+		_ = c.Close()
+	}
+
+	return &ShutdownTimeoutError{Aborted: aborted}
+}
+
+func (a *App) logShutdownProgress(ctx context.Context) {
+	ticker := time.NewTicker(shutdownProgressInterval)
+	defer ticker.Stop()
+
+	deadline, _ := ctx.Deadline()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			a.cfg.logger.Info("Waiting for in-flight requests",
+				"requests", a.InFlight(),
+				"connections", a.OpenConnections(),
+				"remaining", time.Until(deadline).Round(time.Second))
+		}
+	}
+}
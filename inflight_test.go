@@ -0,0 +1,43 @@
+package sgsr
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestShutdownTimeoutReportsAbortedRequests(t *testing.T) {
+	a, fa := newTestApp(t, func(c Config) Config {
+		return c.WithShutdownTimeout(100 * time.Millisecond)
+	})
+	release := make(chan struct{})
+	defer close(release)
+	fa.Get("/slow", func(c *fiber.Ctx) error {
+		<-release
+		return nil
+	})
+
+	if err := a.Start(); err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		if resp, err := http.Get("http://" + a.Addr().String() + "/slow"); err == nil {
+			resp.Body.Close()
+		}
+	}()
+	for a.InFlight() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	err := stopApp(t, a)
+	var timeout *ShutdownTimeoutError
+	if !errors.As(err, &timeout) || !errors.Is(err, ErrShutdownTimeout) {
+		t.Fatalf("Stop() = %v, want ShutdownTimeoutError", err)
+	}
+	if len(timeout.Aborted) != 1 || timeout.Aborted[0].Path != "/slow" {
+		t.Fatalf("Aborted = %+v, want the /slow request", timeout.Aborted)
+	}
+}
@@ -0,0 +1,156 @@
+//go:build linux
+
+package sgsr
+
+import (
+	"context"
+	"encoding/binary"
+	"log/slog"
+	"net"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+const journalSocket = "/run/systemd/journal/socket"
+
+// JournalHandler sends records to the systemd journal over its native
+// protocol. Attributes become journal fields named by their upper-cased,
+// group-prefixed keys, e.g. http.status as HTTP_STATUS. Of the handler
+// options, Level and AddSource are honoured.
+type JournalHandler struct {
+	conn   *net.UnixConn
+	opts   slog.HandlerOptions
+	prefix string
+	fields []journalField
+}
+
+type journalField struct{ key, value string }
+
+func NewJournalHandler(opts *slog.HandlerOptions) (*JournalHandler, error) {
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: journalSocket, Net: "unixgram"})
+	if err != nil {
+		return nil, err
+	}
+
+	h := &JournalHandler{conn: conn}
+	if opts != nil {
+		h.opts = *opts
+	}
+	return h, nil
+}
+
+func journalHandler(opts *slog.HandlerOptions) (slog.Handler, error) {
+	return NewJournalHandler(opts)
+}
+
+func (h *JournalHandler) Enabled(_ context.Context, level slog.Level) bool {
+	minLevel := slog.LevelInfo
+	if h.opts.Level != nil {
+		minLevel = h.opts.Level.Level()
+	}
+	return level >= minLevel
+}
+
+func (h *JournalHandler) Handle(_ context.Context, r slog.Record) error {
+	fields := append([]journalField{
+		{"MESSAGE", r.Message},
+		{"PRIORITY", journalPriority(r.Level)},
+	}, h.fields...)
+	if h.opts.AddSource && r.PC != 0 {
+		f, _ := runtime.CallersFrames([]uintptr{r.PC}).Next()
+		fields = append(fields,
+			journalField{"CODE_FILE", f.File},
+			journalField{"CODE_LINE", strconv.Itoa(f.Line)},
+			journalField{"CODE_FUNC", f.Function},
+		)
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		fields = appendJournalFields(fields, h.prefix, a)
+		return true
+	})
+
+	var b []byte
+	for _, f := range fields {
+		if !strings.Contains(f.value, "\n") {
+			b = append(b, f.key...)
+			b = append(b, '=')
+			b = append(b, f.value...)
+			b = append(b, '\n')
+			continue
+		}
+		b = append(b, f.key...)
+		b = append(b, '\n')
+		b = binary.LittleEndian.AppendUint64(b, uint64(len(f.value)))
+		b = append(b, f.value...)
+		b = append(b, '\n')
+	}
+
+	_, err := h.conn.Write(b)
+	return err
+}
+
+func (h *JournalHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	c := *h
+	c.fields = append([]journalField{}, h.fields...)
+	for _, a := range attrs {
+		c.fields = appendJournalFields(c.fields, h.prefix, a)
+	}
+	return &c
+}
+
+func (h *JournalHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	c := *h
+	c.prefix = h.prefix + name + "."
+	return &c
+}
+
+func appendJournalFields(fields []journalField, prefix string, a slog.Attr) []journalField {
+	a.Value = a.Value.Resolve()
+	if a.Value.Kind() == slog.KindGroup {
+		if a.Key != "" {
+			prefix += a.Key + "."
+		}
+		for _, ga := range a.Value.Group() {
+			fields = appendJournalFields(fields, prefix, ga)
+		}
+		return fields
+	}
+	if a.Equal(slog.Attr{}) {
+		return fields
+	}
+
+	key := journalKey(prefix + a.Key)
+	if key == "" {
+		return fields
+	}
+	return append(fields, journalField{key, a.Value.String()})
+}
+
+// journalKey maps an attribute key to a valid journal field name: upper case
+// letters, digits and underscores, not starting with an underscore or digit.
+func journalKey(key string) string {
+	b := []byte(strings.ToUpper(key))
+	for i, c := range b {
+		if (c < 'A' || c > 'Z') && (c < '0' || c > '9') {
+			b[i] = '_'
+		}
+	}
+	return strings.TrimLeft(string(b), "_0123456789")
+}
+
+func journalPriority(l slog.Level) string {
+	switch {
+	case l >= slog.LevelError:
+		return "3"
+	case l >= slog.LevelWarn:
+		return "4"
+	case l >= slog.LevelInfo:
+		return "6"
+	default:
+		return "7"
+	}
+}
@@ -0,0 +1,12 @@
+//go:build !linux
+
+package sgsr
+
+import (
+	"errors"
+	"log/slog"
+)
+
+func journalHandler(*slog.HandlerOptions) (slog.Handler, error) {
+	return nil, errors.New("sgsr: the systemd journal is only available on Linux")
+}
@@ -0,0 +1,39 @@
+package sgsr
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+// EnvTerminationGracePeriod is read by WithKubernetesDefaults; set it to the
+// pod's terminationGracePeriodSeconds.
+const EnvTerminationGracePeriod = "TERMINATION_GRACE_PERIOD_SECONDS"
+
+const (
+	k8sGracePeriod      = 30 * time.Second
+	k8sPreShutdownDelay = 5 * time.Second
+	// k8sShutdownMargin leaves time for hooks before the kubelet sends SIGKILL.
+	k8sShutdownMargin = 2 * time.Second
+)
+
+// WithKubernetesDefaults serves the standard probes and, on SIGTERM, fails
+// readiness and keeps serving for 5s while endpoints are updated before
+// draining. The shutdown timeout is fitted into the termination grace period
+// from EnvTerminationGracePeriod (30s, the Kubernetes default, when unset).
+func (c Config) WithKubernetesDefaults() Config {
+	grace := k8sGracePeriod
+	if s, err := strconv.Atoi(os.Getenv(EnvTerminationGracePeriod)); err == nil && s > 0 {
+		grace = time.Duration(s) * time.Second
+	}
+
+	delay := min(k8sPreShutdownDelay, grace/4)
+	timeout := grace - delay - k8sShutdownMargin
+	if timeout <= 0 {
+		timeout = grace - delay
+	}
+
+	return c.WithProbes().
+		WithPreShutdownDelay(delay).
+		WithShutdownTimeout(timeout)
+}
@@ -0,0 +1,75 @@
+package sgsr
+
+import (
+	"context"
+	"log/slog"
+)
+
+// ComponentKey is the attribute naming the package component that logged a
+// record when component levels are configured.
+const ComponentKey = "component"
+
+const (
+	ComponentLifecycle = "lifecycle"
+	ComponentAccess    = "access"
+	ComponentTLS       = "tls"
+)
+
+// ComponentLevelHandler applies a per-component minimum level, picking the
+// component from the ComponentKey attribute added with Logger.With. Records
+// of components without a level are filtered by the wrapped handler.
+type ComponentLevelHandler struct {
+	handler slog.Handler
+	levels  map[string]slog.Leveler
+	level   slog.Leveler
+}
+
+func NewComponentLevelHandler(h slog.Handler, levels map[string]slog.Leveler) *ComponentLevelHandler {
+	return &ComponentLevelHandler{handler: h, levels: levels}
+}
+
+func (h *ComponentLevelHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	if h.level != nil {
+		return level >= h.level.Level()
+	}
+	return h.handler.Enabled(ctx, level)
+}
+
+func (h *ComponentLevelHandler) Handle(ctx context.Context, r slog.Record) error {
+	return h.handler.Handle(ctx, r)
+}
+
+func (h *ComponentLevelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	c := &ComponentLevelHandler{handler: h.handler.WithAttrs(attrs), levels: h.levels, level: h.level}
+	for _, a := range attrs {
+		if a.Key == ComponentKey {
+			if l, ok := h.levels[a.Value.String()]; ok {
+				c.level = l
+			}
+		}
+	}
+	return c
+}
+
+func (h *ComponentLevelHandler) WithGroup(name string) slog.Handler {
+	return &ComponentLevelHandler{handler: h.handler.WithGroup(name), levels: h.levels, level: h.level}
+}
+
+// WithComponentLevels sets minimum levels per component, e.g. access logs at
+// INFO and lifecycle at WARN. The app then tags its records with ComponentKey.
+func (c Config) WithComponentLevels(levels map[string]slog.Leveler) Config {
+	c.componentLevels = levels
+	if c.logger != nil {
+		c.logger = slog.New(NewComponentLevelHandler(c.logger.Handler(), levels))
+	}
+	return c
+}
+
+// componentLogger returns the logger for a component; untagged unless
+// component levels are configured.
+func (c Config) componentLogger(component string) *slog.Logger {
+	if c.componentLevels == nil {
+		return c.logger
+	}
+	return c.logger.With(ComponentKey, component)
+}
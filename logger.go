@@ -0,0 +1,87 @@
+package sgsr
+
+import (
+	"io"
+	"log/slog"
+	"os"
+)
+
+type LogFormat string
+
+const (
+	LogFormatJSON LogFormat = "json"
+	LogFormatText LogFormat = "text"
+	// LogFormatSyslog and LogFormatJournal send records to the local syslog
+	// daemon or the systemd journal instead of Writer.
+	LogFormatSyslog  LogFormat = "syslog"
+	LogFormatJournal LogFormat = "journal"
+)
+
+// LoggerOptions configures NewLoggerWith. Zero values give NewLogger's
+// defaults: info level JSON to stderr with RFC 3339 nanosecond timestamps.
+type LoggerOptions struct {
+	Level      slog.Leveler
+	Format     LogFormat
+	Writer     io.Writer
+	AddSource  bool
+	TimeFormat string
+	// Redact wraps the handler in a RedactHandler with these key patterns.
+	Redact []string
+	// Handlers receive every record next to the formatted output, e.g. an
+	// OTLP logs exporter bridge. Each applies its own level; request
+	// attributes and redaction apply to them as well.
+	Handlers []slog.Handler
+}
+
+func NewLogger() *slog.Logger {
+	return NewLoggerWith(LoggerOptions{})
+}
+
+// NewLoggerWith builds a logger wrapped in a ContextHandler, so request
+// scoped attributes are picked up like with NewLogger. When syslog or the
+// journal is unavailable it falls back to JSON on Writer and logs why.
+func NewLoggerWith(opts LoggerOptions) *slog.Logger {
+	w := opts.Writer
+	if w == nil {
+		w = os.Stderr
+	}
+
+	ho := &slog.HandlerOptions{Level: opts.Level, AddSource: opts.AddSource}
+	if opts.TimeFormat != "" {
+		ho.ReplaceAttr = func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && a.Key == slog.TimeKey && a.Value.Kind() == slog.KindTime {
+				a.Value = slog.StringValue(a.Value.Time().Format(opts.TimeFormat))
+			}
+			return a
+		}
+	}
+
+	var (
+		h   slog.Handler
+		err error
+	)
+	switch opts.Format {
+	case LogFormatText:
+		h = slog.NewTextHandler(w, ho)
+	case LogFormatSyslog:
+		h, err = syslogHandler(ho)
+	case LogFormatJournal:
+		h, err = journalHandler(ho)
+	}
+	if h == nil {
+		h = slog.NewJSONHandler(w, ho)
+	}
+
+	if len(opts.Handlers) > 0 {
+		h = NewMultiHandler(append([]slog.Handler{h}, opts.Handlers...)...)
+	}
+	if len(opts.Redact) > 0 {
+		h = NewRedactHandler(h, opts.Redact...)
+	}
+
+	l := slog.New(NewContextHandler(h))
+	if err != nil {
+		l.Warn("Log output unavailable, using JSON", "format", opts.Format, "error", err)
+	}
+	return l
+}
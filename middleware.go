@@ -0,0 +1,32 @@
+package sgsr
+
+import "slices"
+
+type Middleware string
+
+const (
+	MiddlewareRecover         Middleware = "recover"
+	MiddlewareRequestID       Middleware = "request-id"
+	MiddlewareAccessLog       Middleware = "access-log"
+	MiddlewareSecurityHeaders Middleware = "security-headers"
+)
+
+// WithStandardMiddleware installs the recommended stack: WithRecover,
+// WithRequestID, DefaultAccessLog and WithSecurityHeaders, minus those listed
+// in except. Run always chains them in the right order, so later options
+// (e.g. a sampled WithAccessLog) can still override individual parts.
+func (c Config) WithStandardMiddleware(except ...Middleware) Config {
+	if !slices.Contains(except, MiddlewareRecover) {
+		c = c.WithRecover()
+	}
+	if !slices.Contains(except, MiddlewareRequestID) {
+		c = c.WithRequestID()
+	}
+	if !slices.Contains(except, MiddlewareAccessLog) {
+		c = c.WithAccessLog(DefaultAccessLog())
+	}
+	if !slices.Contains(except, MiddlewareSecurityHeaders) {
+		c = c.WithSecurityHeaders()
+	}
+	return c
+}
@@ -0,0 +1,63 @@
+package sgsr
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+)
+
+const ClientCertLocal = "sgsr.clientCert"
+
+var errNoClientCert = errors.New("tls: no verified client certificate")
+
+func (c Config) WithClientCAs(pool *x509.CertPool) Config {
+	c.clientCAs = pool
+	return c
+}
+
+func (c Config) WithClientVerifier(verify func(cert *x509.Certificate) error) Config {
+	c.verifyClient = verify
+	return c
+}
+
+func (c Config) mutualTLS() bool {
+	return c.clientCAs != nil || c.verifyClient != nil
+}
+
+func (c Config) applyClientAuth(cfg *tls.Config) {
+	if !c.mutualTLS() {
+		return
+	}
+
+	cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	if c.clientCAs != nil {
+		cfg.ClientCAs = c.clientCAs
+	}
+
+	if c.verifyClient != nil {
+		verify := c.verifyClient
+		cfg.VerifyPeerCertificate = func(_ [][]byte, chains [][]*x509.Certificate) error {
+			if len(chains) == 0 || len(chains[0]) == 0 {
+				return errNoClientCert
+			}
+			return verify(chains[0][0])
+		}
+	}
+}
+
+func ClientCert(c *fiber.Ctx) *x509.Certificate {
+	cert, _ := c.Locals(ClientCertLocal).(*x509.Certificate)
+	return cert
+}
+
+func clientCertHandler(next fasthttp.RequestHandler) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		if state := ctx.TLSConnectionState(); state != nil && len(state.PeerCertificates) > 0 {
+			ctx.SetUserValue(ClientCertLocal, state.PeerCertificates[0])
+		}
+		next(ctx)
+	}
+}
@@ -0,0 +1,98 @@
+package sgsr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"slices"
+	"time"
+)
+
+// PreflightCheck is an environment check run by Run before binding. A failing
+// check is logged as a warning, or aborts startup when Fatal is set.
+type PreflightCheck struct {
+	Name  string
+	Fatal bool
+	Check func(ctx context.Context) error
+}
+
+// Abort returns the check with Fatal set.
+func (p PreflightCheck) Abort() PreflightCheck {
+	p.Fatal = true
+	return p
+}
+
+// WithPreflight adds checks run in order before the listener is bound.
+func (c Config) WithPreflight(checks ...PreflightCheck) Config {
+	c.preflight = append(slices.Clip(c.preflight), checks...)
+	return c
+}
+
+// FDLimitCheck fails when the open file limit leaves less than conns file
+// descriptors for connections. It always passes where the limit is unknown.
+func FDLimitCheck(conns int) PreflightCheck {
+	return PreflightCheck{Name: "fd-limit", Check: func(context.Context) error {
+		limit, ok := fdLimit()
+		if ok && limit < uint64(conns)+fdReserve {
+			return fmt.Errorf("open file limit %d is below %d expected connections", limit, conns)
+		}
+		return nil
+	}}
+}
+
+// fdReserve covers listeners, log files and other descriptors not used by
+// connections.
+const fdReserve = 64
+
+// TempDirCheck fails when os.TempDir is not writable.
+func TempDirCheck() PreflightCheck {
+	return PreflightCheck{Name: "temp-dir", Check: func(context.Context) error {
+		f, err := os.CreateTemp("", "sgsr-preflight-*")
+		if err != nil {
+			return err
+		}
+		return errors.Join(f.Close(), os.Remove(f.Name()))
+	}}
+}
+
+// PortCheck fails when addr cannot be bound, e.g. because another process
+// holds it. Don't combine it with WithUpgradeSignal or WithPrefork, where the
+// port is legitimately held by a related process.
+func PortCheck(network, addr string) PreflightCheck {
+	return PreflightCheck{Name: "port", Check: func(ctx context.Context) error {
+		ln, err := (&net.ListenConfig{}).Listen(ctx, network, addr)
+		if err != nil {
+			return err
+		}
+		return ln.Close()
+	}}
+}
+
+// ClockCheck fails when the wall clock is before notBefore, typically the
+// build time; certificate validation and tokens break on such hosts.
+func ClockCheck(notBefore time.Time) PreflightCheck {
+	return PreflightCheck{Name: "clock", Check: func(context.Context) error {
+		if now := time.Now(); now.Before(notBefore) {
+			return fmt.Errorf("clock %s is before %s", now.Format(time.RFC3339), notBefore.Format(time.RFC3339))
+		}
+		return nil
+	}}
+}
+
+func (a *App) runPreflight(ctx context.Context) error {
+	var errs []error
+	for _, p := range a.cfg.preflight {
+		err := p.Check(ctx)
+		switch {
+		case err == nil:
+		case p.Fatal:
+			a.cfg.logger.Error("Preflight check failed", "check", p.Name, "error", err)
+			errs = append(errs, fmt.Errorf("preflight %s: %w", p.Name, err))
+		default:
+			a.cfg.logger.Warn("Preflight check failed", "check", p.Name, "error", err)
+		}
+	}
+	return errors.Join(errs...)
+}
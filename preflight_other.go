@@ -0,0 +1,7 @@
+//go:build !unix
+
+package sgsr
+
+func fdLimit() (uint64, bool) {
+	return 0, false
+}
@@ -0,0 +1,151 @@
+package sgsr
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"os/signal"
+	"runtime"
+	"syscall"
+)
+
+const envPreforkChild = "SGSR_PREFORK_CHILD"
+
+// preforkStopFD is the pipe a prefork child watches; the parent closing it
+// (or dying) starts the child's graceful shutdown.
+const preforkStopFD = 4
+
+// WithPrefork serves the app from n child processes sharing one listening
+// socket (GOMAXPROCS children when n <= 0). The parent owns signal handling
+// and shuts all children down gracefully together. Fiber's own Prefork
+// setting must stay disabled.
+func (c Config) WithPrefork(n int) Config {
+	if n <= 0 {
+		n = runtime.GOMAXPROCS(0)
+	}
+	c.prefork = n
+	return c
+}
+
+func isPreforkChild() bool {
+	return os.Getenv(envPreforkChild) != ""
+}
+
+func (a *App) preforkChild() {
+	runtime.GOMAXPROCS(1)
+	signal.Ignore(syscall.SIGINT, syscall.SIGTERM)
+	a.cfg.signals = []os.Signal{}
+
+	stop := os.NewFile(preforkStopFD, "prefork-stop")
+	go func() {
+		_, _ = io.Copy(io.Discard, stop)
+		a.stop()
+	}()
+}
+
+type preforkChild struct {
+	cmd  *exec.Cmd
+	stop *os.File
+	done chan struct{}
+	err  error
+}
+
+func (a *App) runPreforkParent() error {
+	ctx, cancel := a.notifyContext()
+	defer cancel()
+
+	ln, err := net.Listen(a.cfg.app.Config().Network, a.cfg.addr)
+	if err != nil {
+		a.cfg.logger.Error(err.Error())
+		return fmt.Errorf("%w: %w", ErrStartup, err)
+	}
+	defer ln.Close()
+
+	fl, ok := ln.(interface{ File() (*os.File, error) })
+	if !ok {
+		return fmt.Errorf("%w: %w", ErrStartup, errNoListenerFile)
+	}
+	lf, err := fl.File()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrStartup, err)
+	}
+	defer lf.Close()
+
+	exited := make(chan *preforkChild, a.cfg.prefork)
+	children := make([]*preforkChild, 0, a.cfg.prefork)
+	defer func() {
+		for _, ch := range children {
+			_ = ch.stop.Close()
+		}
+		for _, ch := range children {
+			<-ch.done
+		}
+	}()
+
+	for range a.cfg.prefork {
+		ch, err := startPreforkChild(lf, exited)
+		if err != nil {
+			a.cfg.logger.Error("Prefork child failed to start", "error", err)
+			return fmt.Errorf("%w: %w", ErrStartup, err)
+		}
+		children = append(children, ch)
+	}
+
+	a.setListening(ln.Addr())
+	a.setRunning()
+	a.cfg.logger.Info("Status", "Listening addr", ln.Addr().String(), "prefork", len(children))
+
+	go func() {
+		<-a.quit
+		cancel()
+	}()
+
+	select {
+	case <-ctx.Done():
+		a.setCause(CauseContext, nil, context.Cause(ctx))
+		a.setState(Draining)
+		a.cfg.logger.Info("Trying to shut down gracefully")
+		return nil
+	case ch := <-exited:
+		a.setState(Draining)
+		err := fmt.Errorf("%w: prefork child %d exited unexpectedly: %v", ErrServe, ch.cmd.Process.Pid, ch.err)
+		a.cfg.logger.Error(err.Error())
+		a.setCause(CauseListener, nil, err)
+		return err
+	}
+}
+
+func startPreforkChild(listener *os.File, exited chan<- *preforkChild) (*preforkChild, error) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	exe, err := os.Executable()
+	if err != nil {
+		_ = w.Close()
+		return nil, err
+	}
+
+	cmd := exec.Command(exe, os.Args[1:]...)
+	cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
+	cmd.Env = append(os.Environ(), envListenerFD+"=3", envPreforkChild+"=1")
+	cmd.ExtraFiles = []*os.File{listener, r}
+	if err := cmd.Start(); err != nil {
+		_ = w.Close()
+		return nil, err
+	}
+
+	ch := &preforkChild{cmd: cmd, stop: w, done: make(chan struct{})}
+	go func() {
+		ch.err = cmd.Wait()
+		close(ch.done)
+		exited <- ch
+	}()
+
+	return ch, nil
+}
@@ -0,0 +1,108 @@
+package sgsr
+
+import (
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+)
+
+const (
+	DefaultLivenessPath  = "/livez"
+	DefaultReadinessPath = "/readyz"
+	DefaultStartupPath   = "/startupz"
+)
+
+// WithReadiness serves a readiness probe on path (DefaultReadinessPath when
+// empty). It answers 200 while the app is Running, startup tasks are done and
+// registered health checks pass, and 503 otherwise, including the whole
+// drain window.
+func (c Config) WithReadiness(path string) Config {
+	if path == "" {
+		path = DefaultReadinessPath
+	}
+	c.readinessPath = path
+	return c
+}
+
+// WithProbes serves liveness, readiness and startup probes on their default
+// paths. Liveness never runs health checks.
+func (c Config) WithProbes() Config {
+	c.livenessPath = DefaultLivenessPath
+	c.readinessPath = DefaultReadinessPath
+	c.startupPath = DefaultStartupPath
+	return c
+}
+
+type startupTasks struct {
+	mu      sync.Mutex
+	pending map[string]int
+}
+
+// StartupTask marks async startup work (preload, migrations) as pending until
+// the returned func is called; the startup probe fails meanwhile.
+func (a *App) StartupTask(name string) func() {
+	a.startup.mu.Lock()
+	defer a.startup.mu.Unlock()
+
+	if a.startup.pending == nil {
+		a.startup.pending = make(map[string]int)
+	}
+	a.startup.pending[name]++
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			a.startup.mu.Lock()
+			defer a.startup.mu.Unlock()
+
+			if a.startup.pending[name]--; a.startup.pending[name] <= 0 {
+				delete(a.startup.pending, name)
+			}
+		})
+	}
+}
+
+func (a *App) pendingStartup() []string {
+	a.startup.mu.Lock()
+	defer a.startup.mu.Unlock()
+
+	names := make([]string, 0, len(a.startup.pending))
+	for name := range a.startup.pending {
+		names = append(names, name)
+	}
+	return names
+}
+
+func (a *App) started() bool {
+	state := a.State()
+	return (state == Running || state == Draining) && len(a.pendingStartup()) == 0
+}
+
+func (a *App) probesHandler(next fasthttp.RequestHandler) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		switch path := string(ctx.Path()); {
+		case path == a.cfg.livenessPath:
+			state := a.State()
+			writeJSON(ctx, state != Stopped, fiber.Map{"status": state.String()})
+		case path == a.cfg.startupPath:
+			pending := a.pendingStartup()
+			writeJSON(ctx, a.started(), fiber.Map{"status": a.State().String(), "pending": pending})
+		case path == a.cfg.readinessPath:
+			a.readiness(ctx)
+		default:
+			next(ctx)
+		}
+	}
+}
+
+func (a *App) readiness(ctx *fasthttp.RequestCtx) {
+	state := a.State()
+	if pending := a.pendingStartup(); state != Running || len(pending) > 0 {
+		writeJSON(ctx, false, fiber.Map{"status": state.String(), "pending": pending})
+		return
+	}
+
+	report := a.Health(ctx)
+	writeJSON(ctx, report.Status == "ok", fiber.Map{"status": state.String(), "checks": report.Checks})
+}
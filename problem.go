@@ -0,0 +1,100 @@
+package sgsr
+
+import (
+	"context"
+	"errors"
+	"html"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const MIMEApplicationProblemJSON = "application/problem+json"
+
+// Problem is an RFC 7807 problem detail. Handlers may return it as an error
+// to control the response of ProblemErrorHandler.
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+}
+
+func (p *Problem) Error() string {
+	if p.Detail != "" {
+		return p.Title + ": " + p.Detail
+	}
+	return p.Title
+}
+
+func NewProblem(status int, detail string) *Problem {
+	return &Problem{Type: "about:blank", Title: http.StatusText(status), Status: status, Detail: detail}
+}
+
+// ProblemErrorHandler is a fiber.ErrorHandler writing application/problem+json
+// responses; install it with fiber.Config{ErrorHandler: ProblemErrorHandler}.
+// Clients preferring text/html or text/plain in Accept, such as browsers, get
+// the same problem as a minimal page or text instead. Messages of unknown
+// errors are not exposed to clients.
+func ProblemErrorHandler(c *fiber.Ctx, err error) error {
+	p := problemFor(err)
+	if p.Instance == "" {
+		p.Instance = c.OriginalURL()
+	}
+
+	c.Status(p.Status)
+	c.Vary(fiber.HeaderAccept)
+	switch c.Accepts(MIMEApplicationProblemJSON, fiber.MIMEApplicationJSON, fiber.MIMETextHTML, fiber.MIMETextPlain) {
+	case fiber.MIMETextHTML:
+		c.Set(fiber.HeaderContentType, fiber.MIMETextHTMLCharsetUTF8)
+		return c.SendString(p.html())
+	case fiber.MIMETextPlain:
+		c.Set(fiber.HeaderContentType, fiber.MIMETextPlainCharsetUTF8)
+		return c.SendString(p.Error() + "\n")
+	default:
+		return c.JSON(p, MIMEApplicationProblemJSON)
+	}
+}
+
+func (p *Problem) html() string {
+	title := html.EscapeString(strconv.Itoa(p.Status) + " " + p.Title)
+	var b strings.Builder
+	b.WriteString("<!DOCTYPE html>\n<html><head><meta charset=\"utf-8\"><title>")
+	b.WriteString(title)
+	b.WriteString("</title></head><body><h1>")
+	b.WriteString(title)
+	b.WriteString("</h1>")
+	if p.Detail != "" {
+		b.WriteString("<p>")
+		b.WriteString(html.EscapeString(p.Detail))
+		b.WriteString("</p>")
+	}
+	b.WriteString("</body></html>\n")
+	return b.String()
+}
+
+func problemFor(err error) *Problem {
+	var p *Problem
+	if errors.As(err, &p) {
+		cp := *p
+		return &cp
+	}
+
+	var fe *fiber.Error
+	switch {
+	case errors.As(err, &fe):
+		return NewProblem(fe.Code, fe.Message)
+	case errors.Is(err, context.DeadlineExceeded):
+		return NewProblem(fiber.StatusGatewayTimeout, "")
+	case errors.Is(err, context.Canceled),
+		errors.Is(err, ErrStartup),
+		errors.Is(err, ErrServe),
+		errors.Is(err, ErrShutdownTimeout):
+		return NewProblem(fiber.StatusServiceUnavailable, "")
+	default:
+		return NewProblem(fiber.StatusInternalServerError, "")
+	}
+}
@@ -0,0 +1,54 @@
+package sgsr
+
+import (
+	"log/slog"
+	"time"
+)
+
+// Profile selects a group of defaults. Its option methods return plain
+// structs, so single settings can be overridden before use:
+//
+//	opts := sgsr.ProfileDev.LoggerOptions()
+//	opts.AddSource = false
+//	logger := sgsr.NewLoggerWith(opts)
+type Profile string
+
+const (
+	ProfileDev  Profile = "dev"
+	ProfileProd Profile = "prod"
+)
+
+// LoggerOptions gives text logs at debug level for ProfileDev and JSON logs at
+// info level otherwise.
+func (p Profile) LoggerOptions() LoggerOptions {
+	if p == ProfileDev {
+		return LoggerOptions{Level: slog.LevelDebug, Format: LogFormatText, AddSource: true}
+	}
+	return LoggerOptions{Level: slog.LevelInfo, Format: LogFormatJSON}
+}
+
+// FiberOptions relaxes the timeouts for ProfileDev so requests survive a
+// debugger pause; other profiles keep NewFiberApp's strict defaults.
+func (p Profile) FiberOptions() FiberOptions {
+	if p == ProfileDev {
+		return FiberOptions{
+			ReadTimeout:  5 * time.Minute,
+			WriteTimeout: 5 * time.Minute,
+			IdleTimeout:  5 * time.Minute,
+		}
+	}
+	return FiberOptions{}
+}
+
+// WithProfile applies the profile's Config defaults. Both profiles install
+// WithStandardMiddleware minus the middleware listed in except; ProfileDev
+// also enables WithStartupSummary. Nothing else differs between them. The
+// middleware cannot be switched off by later options, so leave it out here;
+// later options can still replace it, e.g. a sampled WithAccessLog.
+func (c Config) WithProfile(p Profile, except ...Middleware) Config {
+	c = c.WithStandardMiddleware(except...)
+	if p == ProfileDev {
+		c = c.WithStartupSummary()
+	}
+	return c
+}
@@ -0,0 +1,203 @@
+package sgsr
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+)
+
+const defaultProxyTimeout = 30 * time.Second
+
+// ProxyOptions configures App.RegisterProxy. Headers are set on every upstream
+// request; PreserveHost forwards the client's Host header instead of the
+// upstream's. Retries applies to idempotent methods only.
+type ProxyOptions struct {
+	Timeout      time.Duration
+	Retries      int
+	Headers      map[string]string
+	PreserveHost bool
+}
+
+// Proxy forwards requests under a prefix to an upstream. Close stops taking
+// new requests, waits for outstanding ones and closes idle upstream
+// connections; App.RegisterProxy arranges for that during shutdown.
+type Proxy struct {
+	client  *fasthttp.HostClient
+	scheme  string
+	host    string
+	path    string
+	opts    ProxyOptions
+	wg      sync.WaitGroup
+	mu      sync.RWMutex
+	closing bool
+}
+
+var hopHeaders = [...]string{
+	fasthttp.HeaderConnection,
+	fasthttp.HeaderKeepAlive,
+	fasthttp.HeaderProxyAuthenticate,
+	fasthttp.HeaderProxyAuthorization,
+	fasthttp.HeaderTE,
+	fasthttp.HeaderTrailer,
+	fasthttp.HeaderTransferEncoding,
+	fasthttp.HeaderUpgrade,
+}
+
+// RegisterProxy forwards all requests under prefix to upstream, an http or
+// https URL whose path is prepended to the remainder of the request path.
+// router may be a group; the path forwarded is relative to prefix within it.
+// The proxy is closed as a shutdown hook, after the server stopped taking
+// requests.
+func (a *App) RegisterProxy(router fiber.Router, prefix, upstream string, opts ProxyOptions) (*Proxy, error) {
+	u, err := url.Parse(upstream)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+		return nil, fmt.Errorf("sgsr: invalid proxy upstream %q", upstream)
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaultProxyTimeout
+	}
+
+	addr := u.Host
+	if u.Port() == "" {
+		port := "80"
+		if u.Scheme == "https" {
+			port = "443"
+		}
+		addr = net.JoinHostPort(u.Hostname(), port)
+	}
+
+	p := &Proxy{
+		client: &fasthttp.HostClient{
+			Addr:                     addr,
+			IsTLS:                    u.Scheme == "https",
+			NoDefaultUserAgentHeader: true,
+			DisablePathNormalizing:   true,
+		},
+		scheme: u.Scheme,
+		host:   u.Host,
+		path:   strings.TrimSuffix(u.Path, "/"),
+		opts:   opts,
+	}
+	prefix = strings.TrimSuffix(prefix, "/")
+	router.All(prefix, p.handle)
+	router.All(prefix+"/*", p.handle)
+	a.Manage("proxy "+upstream, p)
+
+	return p, nil
+}
+
+func (p *Proxy) handle(c *fiber.Ctx) error {
+	if !p.acquire() {
+		return fiber.ErrServiceUnavailable
+	}
+	defer p.wg.Done()
+
+	req := fasthttp.AcquireRequest()
+	defer fasthttp.ReleaseRequest(req)
+	c.Request().CopyTo(req)
+
+	req.SetRequestURI(p.upstreamPath(c))
+	req.URI().SetScheme(p.scheme)
+	req.URI().SetQueryStringBytes(c.Request().URI().QueryString())
+	if !p.opts.PreserveHost {
+		req.SetHost(p.host)
+		req.Header.SetHost(p.host)
+	}
+	delHopHeaders(&req.Header)
+	forwarded := c.IP()
+	if prior := req.Header.Peek(fiber.HeaderXForwardedFor); len(prior) > 0 {
+		forwarded = string(prior) + ", " + forwarded
+	}
+	req.Header.Set(fiber.HeaderXForwardedFor, forwarded)
+	req.Header.Set(fiber.HeaderXForwardedHost, c.Hostname())
+	req.Header.Set(fiber.HeaderXForwardedProto, c.Protocol())
+	for k, v := range p.opts.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp := c.Response()
+	err := p.client.DoTimeout(req, resp, p.opts.Timeout)
+	idempotent := req.Header.IsGet() || req.Header.IsHead() || req.Header.IsOptions() || req.Header.IsPut() || req.Header.IsDelete()
+	for i := 0; err != nil && idempotent && i < p.opts.Retries; i++ {
+		err = p.client.DoTimeout(req, resp, p.opts.Timeout)
+	}
+	switch {
+	case errors.Is(err, fasthttp.ErrTimeout):
+		return fiber.ErrGatewayTimeout
+	case err != nil:
+		return fiber.ErrBadGateway
+	}
+
+	delHopHeaders(&resp.Header)
+	return nil
+}
+
+// hopHeaderDeleter is the part of the request and response headers
+// delHopHeaders uses.
+type hopHeaderDeleter interface {
+	Peek(key string) []byte
+	Del(key string)
+}
+
+// delHopHeaders removes the standard hop-by-hop headers and any header the
+// Connection header names.
+func delHopHeaders(h hopHeaderDeleter) {
+	for _, name := range strings.Split(string(h.Peek(fasthttp.HeaderConnection)), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			h.Del(name)
+		}
+	}
+	for _, name := range hopHeaders {
+		h.Del(name)
+	}
+}
+
+// upstreamPath maps the path matched by the wildcard, which excludes any
+// group and proxy prefix, under the upstream path.
+// Without strict routing the wildcard loses a trailing slash, so it is taken
+// from the original path.
+func (p *Proxy) upstreamPath(c *fiber.Ctx) string {
+	rest := c.Params("*")
+	trailing := strings.HasSuffix(string(c.Request().URI().PathOriginal()), "/")
+	switch {
+	case rest == "" && !trailing:
+		if p.path == "" {
+			return "/"
+		}
+		return p.path
+	case rest != "" && trailing && !strings.HasSuffix(rest, "/"):
+		rest += "/"
+	}
+	return p.path + "/" + rest
+}
+
+func (p *Proxy) acquire() bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.closing {
+		return false
+	}
+	p.wg.Add(1)
+	return true
+}
+
+func (p *Proxy) Close() error {
+	p.mu.Lock()
+	p.closing = true
+	p.mu.Unlock()
+
+	p.wg.Wait()
+	p.client.CloseIdleConnections()
+	return nil
+}
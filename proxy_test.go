@@ -0,0 +1,106 @@
+package sgsr
+
+import (
+	"io"
+	"net"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// startUpstream serves an app that echoes the request URI and the headers
+// the tests look at.
+func startUpstream(t *testing.T) string {
+	t.Helper()
+
+	up := fiber.New(fiber.Config{DisableStartupMessage: true})
+	up.All("/*", func(c *fiber.Ctx) error {
+		c.Set("X-Seen-Forwarded-For", c.Get(fiber.HeaderXForwardedFor))
+		c.Set("X-Seen-Private", c.Get("X-Private"))
+		c.Set("X-Private-Reply", "secret")
+		c.Set(fiber.HeaderConnection, "X-Private-Reply")
+		return c.SendString(string(c.Request().RequestURI()))
+	})
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() { _ = up.Listener(ln) }()
+	t.Cleanup(func() { _ = up.Shutdown() })
+
+	return "http://" + ln.Addr().String()
+}
+
+func TestProxyUpstreamPath(t *testing.T) {
+	upstream := startUpstream(t)
+
+	for _, tt := range []struct {
+		name   string
+		group  string
+		prefix string
+		base   string
+		path   string
+		want   string
+	}{
+		{"prefix root", "", "/api", "", "/api", "/"},
+		{"prefix root trailing slash", "", "/api", "", "/api/", "/"},
+		{"prefix path", "", "/api", "", "/api/users/1", "/users/1"},
+		{"prefix path trailing slash", "", "/api", "", "/api/users/", "/users/"},
+		{"prefix with trailing slash", "", "/api/", "", "/api/users", "/users"},
+		{"upstream base", "", "/api", "/v1", "/api/users", "/v1/users"},
+		{"upstream base root", "", "/api", "/v1/", "/api", "/v1"},
+		{"upstream base root trailing slash", "", "/api", "/v1", "/api/", "/v1/"},
+		{"group", "/svc", "/api", "", "/svc/api/users", "/users"},
+		{"group with base", "/svc", "/api", "/v1", "/svc/api/users?q=1", "/v1/users?q=1"},
+		{"query", "", "/api", "", "/api/search?q=a%20b", "/search?q=a%20b"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			a, fa := newTestApp(t, nil)
+			var router fiber.Router = fa
+			if tt.group != "" {
+				router = fa.Group(tt.group)
+			}
+			if _, err := a.RegisterProxy(router, tt.prefix, upstream+tt.base, ProxyOptions{}); err != nil {
+				t.Fatal(err)
+			}
+
+			resp, err := fa.Test(httptest.NewRequest(fiber.MethodGet, tt.path, nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			body, _ := io.ReadAll(resp.Body)
+			if resp.StatusCode != fiber.StatusOK || string(body) != tt.want {
+				t.Fatalf("GET %s = %d %q, want upstream path %q", tt.path, resp.StatusCode, body, tt.want)
+			}
+		})
+	}
+}
+
+func TestProxyHeaders(t *testing.T) {
+	a, fa := newTestApp(t, nil)
+	if _, err := a.RegisterProxy(fa, "/api", startUpstream(t), ProxyOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	req := httptest.NewRequest(fiber.MethodGet, "/api/", nil)
+	req.Header.Set(fiber.HeaderXForwardedFor, "203.0.113.7")
+	req.Header.Set(fiber.HeaderConnection, "keep-alive, X-Private")
+	req.Header.Set("X-Private", "token")
+	resp, err := fa.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if got := resp.Header.Get("X-Seen-Forwarded-For"); got != "203.0.113.7, 0.0.0.0" {
+		t.Errorf("upstream X-Forwarded-For = %q, want the client appended", got)
+	}
+	if got := resp.Header.Get("X-Seen-Private"); got != "" {
+		t.Errorf("upstream X-Private = %q, want it stripped as hop-by-hop", got)
+	}
+	if got := resp.Header.Get("X-Private-Reply"); got != "" {
+		t.Errorf("response X-Private-Reply = %q, want it stripped as hop-by-hop", got)
+	}
+}
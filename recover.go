@@ -0,0 +1,54 @@
+package sgsr
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+
+	"github.com/valyala/fasthttp"
+)
+
+// WithRecover turns handler panics into a problem+json 500 response and logs
+// them with the goroutine stack.
+func (c Config) WithRecover() Config {
+	c.recover = true
+	return c
+}
+
+// Panics returns how many handler panics were recovered.
+func (a *App) Panics() uint64 {
+	return a.panics.Load()
+}
+
+func (a *App) recoverHandler(next fasthttp.RequestHandler) fasthttp.RequestHandler {
+	body, _ := json.Marshal(NewProblem(fasthttp.StatusInternalServerError, ""))
+	return func(ctx *fasthttp.RequestCtx) {
+		defer func() {
+			r := recover()
+			if r == nil {
+				return
+			}
+
+			a.panics.Add(1)
+			a.cfg.logger.LogAttrs(ctx, slog.LevelError, "Panic recovered",
+				slog.String("panic", fmt.Sprint(r)),
+				slog.String("method", string(ctx.Method())),
+				slog.String("path", string(ctx.Path())),
+				slog.String("stack", string(debug.Stack())),
+			)
+
+			// Keep the request ID set by the outer middleware so the
+			// failure can be correlated with the log line above.
+			id := string(ctx.Response.Header.Peek(RequestIDHeader))
+			ctx.Response.Reset()
+			if id != "" {
+				ctx.Response.Header.Set(RequestIDHeader, id)
+			}
+			ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+			ctx.SetContentType(MIMEApplicationProblemJSON)
+			ctx.SetBody(body)
+		}()
+		next(ctx)
+	}
+}
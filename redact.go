@@ -0,0 +1,126 @@
+package sgsr
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/valyala/fasthttp"
+)
+
+const redacted = "[REDACTED]"
+
+// DefaultRedactKeys are the key patterns WithRedaction uses when none are
+// given.
+var DefaultRedactKeys = []string{"authorization", "cookie", "password", "secret", "token", "api_key", "apikey"}
+
+// redactor matches attribute keys containing any of its patterns, ignoring
+// case.
+type redactor []string
+
+func newRedactor(patterns []string) redactor {
+	if len(patterns) == 0 {
+		patterns = DefaultRedactKeys
+	}
+	r := make(redactor, len(patterns))
+	for i, p := range patterns {
+		r[i] = strings.ToLower(p)
+	}
+	return r
+}
+
+func (r redactor) matches(key string) bool {
+	key = strings.ToLower(key)
+	for _, p := range r {
+		if strings.Contains(key, p) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r redactor) attr(a slog.Attr) slog.Attr {
+	if r.matches(a.Key) {
+		return slog.String(a.Key, redacted)
+	}
+	a.Value = a.Value.Resolve()
+	if a.Value.Kind() != slog.KindGroup {
+		return a
+	}
+	group := a.Value.Group()
+	attrs := make([]slog.Attr, len(group))
+	for i, ga := range group {
+		attrs[i] = r.attr(ga)
+	}
+	return slog.Attr{Key: a.Key, Value: slog.GroupValue(attrs...)}
+}
+
+func (r redactor) attrs(as []slog.Attr) []slog.Attr {
+	out := make([]slog.Attr, len(as))
+	for i, a := range as {
+		out[i] = r.attr(a)
+	}
+	return out
+}
+
+// query renders the query string with the values of matching keys replaced.
+func (r redactor) query(args *fasthttp.Args) string {
+	var b strings.Builder
+	args.VisitAll(func(key, value []byte) {
+		if b.Len() > 0 {
+			b.WriteByte('&')
+		}
+		b.Write(key)
+		b.WriteByte('=')
+		if r.matches(string(key)) {
+			b.WriteString(redacted)
+		} else {
+			b.Write(value)
+		}
+	})
+	return b.String()
+}
+
+// RedactHandler replaces the values of attributes whose keys match one of
+// its patterns, including attributes nested in groups.
+type RedactHandler struct {
+	handler slog.Handler
+	redact  redactor
+}
+
+// NewRedactHandler wraps h; without patterns it uses DefaultRedactKeys.
+func NewRedactHandler(h slog.Handler, patterns ...string) *RedactHandler {
+	return &RedactHandler{handler: h, redact: newRedactor(patterns)}
+}
+
+func (h *RedactHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.handler.Enabled(ctx, level)
+}
+
+func (h *RedactHandler) Handle(ctx context.Context, r slog.Record) error {
+	out := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		out.AddAttrs(h.redact.attr(a))
+		return true
+	})
+	return h.handler.Handle(ctx, out)
+}
+
+func (h *RedactHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &RedactHandler{handler: h.handler.WithAttrs(h.redact.attrs(attrs)), redact: h.redact}
+}
+
+func (h *RedactHandler) WithGroup(name string) slog.Handler {
+	return &RedactHandler{handler: h.handler.WithGroup(name), redact: h.redact}
+}
+
+// WithRedaction wraps the configured logger in a RedactHandler and makes the
+// access log redact matching query parameters. Without patterns it uses
+// DefaultRedactKeys.
+func (c Config) WithRedaction(patterns ...string) Config {
+	c.redact = newRedactor(patterns)
+	if c.logger != nil {
+		c.logger = slog.New(&RedactHandler{handler: c.logger.Handler(), redact: c.redact})
+	}
+	return c
+}
@@ -0,0 +1,79 @@
+package sgsr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+type reloadHook struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+// WithReloadSignal replaces SIGHUP as the signal that triggers App.Reload.
+func (c Config) WithReloadSignal(sig os.Signal) Config {
+	c.reloadSignal = sig
+	return c
+}
+
+// OnReload registers fn to run on every reload, in registration order.
+// Reloads never restart the listener.
+func (a *App) OnReload(name string, fn func(ctx context.Context) error) {
+	a.reloadHooks = append(a.reloadHooks, reloadHook{name: name, fn: fn})
+}
+
+// Reload re-reads the TLS certificate files, if any, and runs the OnReload
+// hooks. A failing hook is logged and does not stop the remaining ones.
+func (a *App) Reload(ctx context.Context) error {
+	a.reloadMu.Lock()
+	defer a.reloadMu.Unlock()
+
+	a.cfg.logger.Info("Reloading")
+
+	var errs []error
+	if a.certs != nil {
+		if err := a.certs.load(); err != nil {
+			a.cfg.logger.Error("TLS certificate reload failed", "error", err)
+			errs = append(errs, fmt.Errorf("tls certificates: %w", err))
+		}
+	}
+
+	for _, h := range a.reloadHooks {
+		started := time.Now()
+		if err := h.fn(ctx); err != nil {
+			a.cfg.logger.Error("Reload hook failed", "hook", h.name, "duration", time.Since(started), "error", err)
+			a.emitHook("reload", h.name, time.Since(started), err)
+			errs = append(errs, &HookError{Phase: "reload", Name: h.name, Err: err})
+			continue
+		}
+		a.cfg.logger.Info("Reload hook completed", "hook", h.name, "duration", time.Since(started))
+		a.emitHook("reload", h.name, time.Since(started), nil)
+	}
+
+	return errors.Join(errs...)
+}
+
+func (a *App) watchReload(ctx context.Context) {
+	sig := a.cfg.reloadSignal
+	if sig == nil {
+		sig = syscall.SIGHUP
+	}
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, sig)
+	defer signal.Stop(sigs)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-sigs:
+			_ = a.Reload(ctx)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package sgsr
+
+import (
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+)
+
+type ShutdownCause string
+
+const (
+	CauseSignal   ShutdownCause = "signal"
+	CauseShutdown ShutdownCause = "shutdown"
+	CauseContext  ShutdownCause = "context"
+	CauseListener ShutdownCause = "listener"
+	CauseStartup  ShutdownCause = "startup"
+	CauseWorker   ShutdownCause = "worker"
+	CauseUpgrade  ShutdownCause = "upgrade"
+	CauseService  ShutdownCause = "service"
+)
+
+// ShutdownReport describes why Run returned and how long each shutdown phase
+// took. Signal is set for CauseSignal, Err for the failure that caused the
+// stop.
+type ShutdownReport struct {
+	Cause  ShutdownCause
+	Signal os.Signal
+	Err    error
+
+	Uptime  time.Duration
+	Delay   time.Duration
+	Drain   time.Duration
+	Workers time.Duration
+	Hooks   time.Duration
+	Total   time.Duration
+}
+
+func (r ShutdownReport) LogValue() slog.Value {
+	attrs := []slog.Attr{slog.String("cause", string(r.Cause))}
+	if r.Signal != nil {
+		attrs = append(attrs, slog.String("signal", r.Signal.String()))
+	}
+	if r.Err != nil {
+		attrs = append(attrs, slog.String("error", r.Err.Error()))
+	}
+	attrs = append(attrs,
+		slog.Duration("uptime", r.Uptime),
+		slog.Duration("delay", r.Delay),
+		slog.Duration("drain", r.Drain),
+		slog.Duration("workers", r.Workers),
+		slog.Duration("hooks", r.Hooks),
+		slog.Duration("total", r.Total),
+	)
+
+	return slog.GroupValue(attrs...)
+}
+
+type report struct {
+	mu sync.Mutex
+	ShutdownReport
+	set bool
+}
+
+// ShutdownReport returns the report of the last Run; it is complete once
+// Done is closed.
+func (a *App) ShutdownReport() ShutdownReport {
+	a.report.mu.Lock()
+	defer a.report.mu.Unlock()
+
+	return a.report.ShutdownReport
+}
+
+// setCause records the first reason the app was asked to stop.
+func (a *App) setCause(cause ShutdownCause, sig os.Signal, err error) {
+	a.report.mu.Lock()
+	defer a.report.mu.Unlock()
+
+	if a.report.set {
+		return
+	}
+	a.report.set = true
+	a.report.Cause, a.report.Signal, a.report.Err = cause, sig, err
+}
+
+func (a *App) recordPhase(phase *time.Duration, started time.Time) {
+	d := time.Since(started)
+
+	a.report.mu.Lock()
+	defer a.report.mu.Unlock()
+
+	*phase = d
+}
+
+func (a *App) logShutdownReport(stopping time.Time) {
+	a.report.mu.Lock()
+	if !a.report.set {
+		a.report.mu.Unlock()
+		return
+	}
+	if !a.startedAt.IsZero() {
+		a.report.Uptime = time.Since(a.startedAt)
+	}
+	if !stopping.IsZero() {
+		a.report.Total = time.Since(stopping)
+	}
+	r := a.report.ShutdownReport
+	a.report.mu.Unlock()
+
+	a.cfg.logger.Info("Shutdown report", "report", r)
+}
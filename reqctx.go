@@ -0,0 +1,48 @@
+package sgsr
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+)
+
+// WithRequestContext makes c.UserContext of every request a child of
+// App.Context, so handlers and the calls they make are cancelled when
+// shutdown starts. fn, if not nil, can add values or a deadline: it receives
+// the request with UserContext already set to that child, and its result is
+// still cancelled with the app. fn runs before routing, so route parameters
+// are not available to it.
+func (c Config) WithRequestContext(fn func(c *fiber.Ctx) context.Context) Config {
+	c.requestContext = &fn
+	return c
+}
+
+func (a *App) requestContextHandler(next fasthttp.RequestHandler) fasthttp.RequestHandler {
+	fn := *a.cfg.requestContext
+	return func(ctx *fasthttp.RequestCtx) {
+		var rctx context.Context = a.ctx
+		if id, ok := ctx.UserValue(RequestIDLocal).(string); ok {
+			rctx = context.WithValue(rctx, RequestIDLocal, id)
+		}
+
+		c := a.cfg.app.AcquireCtx(ctx)
+		if fn != nil {
+			c.SetUserContext(rctx)
+			if v := fn(c); v != nil {
+				rctx = v
+			}
+		}
+
+		rctx, cancel := context.WithCancelCause(rctx)
+		defer cancel(nil)
+		stop := context.AfterFunc(a.ctx, func() {
+			cancel(context.Cause(a.ctx))
+		})
+		defer stop()
+
+		c.SetUserContext(rctx)
+		a.cfg.app.ReleaseCtx(c)
+		next(ctx)
+	}
+}
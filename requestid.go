@@ -0,0 +1,63 @@
+package sgsr
+
+import (
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+	"github.com/valyala/fasthttp"
+)
+
+const (
+	RequestIDHeader = fiber.HeaderXRequestID
+	RequestIDLocal  = "sgsr.requestID"
+
+	maxRequestIDLen = 128
+)
+
+// WithRequestID propagates X-Request-ID from the request, generating one when
+// it is missing or malformed, and echoes it on the response.
+func (c Config) WithRequestID() Config {
+	c.requestID = true
+	return c
+}
+
+func RequestID(c *fiber.Ctx) string {
+	id, _ := c.Locals(RequestIDLocal).(string)
+	return id
+}
+
+// RequestLogger returns the app logger with the request ID attached. It is
+// never tagged with a component, so WithComponentLevels leaves it alone.
+func (a *App) RequestLogger(c *fiber.Ctx) *slog.Logger {
+	if id := RequestID(c); id != "" {
+		return a.baseLog.With("request_id", id)
+	}
+	return a.baseLog
+}
+
+func validRequestID(id []byte) bool {
+	if len(id) == 0 || len(id) > maxRequestIDLen {
+		return false
+	}
+	for _, b := range id {
+		if b < 0x21 || b > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+func requestIDHandler(next fasthttp.RequestHandler) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		id := ctx.Request.Header.Peek(RequestIDHeader)
+		value := string(id)
+		if !validRequestID(id) {
+			value = utils.UUIDv4()
+		}
+
+		ctx.SetUserValue(RequestIDLocal, value)
+		ctx.Response.Header.Set(RequestIDHeader, value)
+		next(ctx)
+	}
+}
@@ -0,0 +1,93 @@
+package sgsr
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"time"
+)
+
+// RestartPolicy retries serving after the listener fails post-startup.
+// Backoff doubles from InitialBackoff (100ms unless set) up to MaxBackoff (30s
+// unless set) between attempts. MaxAttempts counts consecutive failures: once
+// serving has lasted MaxBackoff the count starts over.
+type RestartPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+func (c Config) WithRestartPolicy(p RestartPolicy) Config {
+	if p.InitialBackoff <= 0 {
+		p.InitialBackoff = 100 * time.Millisecond
+	}
+	if p.MaxBackoff <= 0 {
+		p.MaxBackoff = 30 * time.Second
+	}
+	if p.MaxBackoff < p.InitialBackoff {
+		p.MaxBackoff = p.InitialBackoff
+	}
+	c.restart = &p
+	return c
+}
+
+func (p RestartPolicy) backoff(attempt int) time.Duration {
+	d := p.InitialBackoff
+	for range attempt {
+		d *= 2
+		if d >= p.MaxBackoff {
+			return p.MaxBackoff
+		}
+	}
+	return d
+}
+
+// restartServe waits out the backoff for attempt and serves again, on a new
+// listener if the old one is closed. It reports false when the policy is
+// exhausted or ctx is done.
+func (a *App) restartServe(ctx context.Context, cause error, attempt int, served chan<- error) bool {
+	p := a.cfg.restart
+	if p == nil || attempt >= p.MaxAttempts {
+		return false
+	}
+
+	wait := p.backoff(attempt)
+	a.cfg.logger.Warn("Listener failed, restarting", "attempt", attempt+1, "max_attempts", p.MaxAttempts, "backoff", wait, "error", cause)
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(wait):
+	}
+
+	if errors.Is(cause, net.ErrClosed) {
+		ln, err := a.listen(ctx)
+		if err != nil {
+			a.cfg.logger.Error("Listener restart failed", "attempt", attempt+1, "error", err)
+			served <- err
+			return true
+		}
+		a.ln = &onceCloseListener{Listener: ln}
+	}
+
+	go func() {
+		served <- a.cfg.app.Server().Serve(a.ln)
+	}()
+	return true
+}
+
+// onceCloseListener lets both fasthttp and the app close the listener, even
+// when it was served more than once.
+type onceCloseListener struct {
+	net.Listener
+	once sync.Once
+	err  error
+}
+
+func (l *onceCloseListener) Close() error {
+	l.once.Do(func() {
+		l.err = l.Listener.Close()
+	})
+	return l.err
+}
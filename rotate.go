@@ -0,0 +1,190 @@
+package sgsr
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const rotateTimeFormat = "20060102T150405.000"
+
+// RotateOptions configures a RotatingFile. The file is rotated before a write
+// would grow it beyond MaxSize bytes or once it is older than MaxAge; zero
+// disables either trigger. At most MaxBackups rotated files are kept, all of
+// them when zero.
+type RotateOptions struct {
+	MaxSize    int64
+	MaxAge     time.Duration
+	MaxBackups int
+}
+
+// RotatingFile is an io.WriteCloser for LoggerOptions.Writer. Rotated files
+// are renamed to path plus a timestamp suffix. Register it with App.Manage to
+// close it on shutdown.
+type RotatingFile struct {
+	mu     sync.Mutex
+	path   string
+	opts   RotateOptions
+	file   *os.File
+	size   int64
+	opened time.Time
+}
+
+func NewRotatingFile(path string, opts RotateOptions) (*RotatingFile, error) {
+	r := &RotatingFile{path: path, opts: opts}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *RotatingFile) open() error {
+	f, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	r.file, r.size, r.opened = f, info.Size(), r.started(info)
+	return nil
+}
+
+// started estimates when the current file was begun, so MaxAge survives a
+// restart: at the newest backup's rotation, else at its last write.
+func (r *RotatingFile) started(info os.FileInfo) time.Time {
+	if info.Size() == 0 {
+		return time.Now()
+	}
+	backups, err := r.backups()
+	if err == nil && len(backups) > 0 {
+		if t, ok := r.backupTime(backups[len(backups)-1]); ok {
+			return t
+		}
+	}
+	return info.ModTime()
+}
+
+func (r *RotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return 0, os.ErrClosed
+	}
+	if r.due(len(p)) {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+func (r *RotatingFile) due(n int) bool {
+	if r.size == 0 {
+		return false
+	}
+	return r.opts.MaxSize > 0 && r.size+int64(n) > r.opts.MaxSize ||
+		r.opts.MaxAge > 0 && time.Since(r.opened) >= r.opts.MaxAge
+}
+
+// Rotate closes the current file, renames it and opens a new one.
+func (r *RotatingFile) Rotate() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return os.ErrClosed
+	}
+	return r.rotate()
+}
+
+func (r *RotatingFile) rotate() error {
+	if err := r.file.Close(); err != nil {
+		return err
+	}
+	r.file = nil
+
+	if err := os.Rename(r.path, r.backupName(time.Now())); err != nil {
+		return errors.Join(err, r.open())
+	}
+	if err := r.open(); err != nil {
+		return err
+	}
+	return r.prune()
+}
+
+// backupName returns a backup path for a rotation at t that does not exist
+// yet; rotations within the same millisecond move on to the next one.
+func (r *RotatingFile) backupName(t time.Time) string {
+	for {
+		name := r.path + "." + t.Format(rotateTimeFormat)
+		if _, err := os.Lstat(name); errors.Is(err, fs.ErrNotExist) {
+			return name
+		}
+		t = t.Add(time.Millisecond)
+	}
+}
+
+func (r *RotatingFile) backupTime(name string) (time.Time, bool) {
+	t, err := time.ParseInLocation(rotateTimeFormat, strings.TrimPrefix(name, r.path+"."), time.Local)
+	return t, err == nil
+}
+
+// backups lists the files carrying this writer's timestamp suffix, oldest
+// first; the suffix sorts chronologically.
+func (r *RotatingFile) backups() ([]string, error) {
+	matches, err := filepath.Glob(r.path + ".*")
+	if err != nil {
+		return nil, err
+	}
+	var backups []string
+	for _, m := range matches {
+		if _, ok := r.backupTime(m); ok {
+			backups = append(backups, m)
+		}
+	}
+	slices.Sort(backups)
+	return backups, nil
+}
+
+func (r *RotatingFile) prune() error {
+	if r.opts.MaxBackups <= 0 {
+		return nil
+	}
+
+	backups, err := r.backups()
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for len(backups) > r.opts.MaxBackups {
+		errs = append(errs, os.Remove(backups[0]))
+		backups = backups[1:]
+	}
+	return errors.Join(errs...)
+}
+
+func (r *RotatingFile) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
@@ -0,0 +1,141 @@
+package sgsr
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestRotatingFileDue(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		opts   RotateOptions
+		size   int64
+		write  int
+		opened time.Duration
+		want   bool
+	}{
+		{"empty file", RotateOptions{MaxSize: 1, MaxAge: time.Second}, 0, 10, time.Hour, false},
+		{"no triggers", RotateOptions{}, 100, 10, time.Hour, false},
+		{"below max size", RotateOptions{MaxSize: 100}, 50, 50, 0, false},
+		{"beyond max size", RotateOptions{MaxSize: 100}, 50, 51, 0, true},
+		{"younger than max age", RotateOptions{MaxAge: time.Hour}, 1, 1, time.Minute, false},
+		{"older than max age", RotateOptions{MaxAge: time.Hour}, 1, 1, 2 * time.Hour, true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &RotatingFile{opts: tt.opts, size: tt.size, opened: time.Now().Add(-tt.opened)}
+			if got := r.due(tt.write); got != tt.want {
+				t.Fatalf("due(%d) = %v, want %v", tt.write, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRotatingFileBackupName(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	r := &RotatingFile{path: path}
+	at := time.Date(2024, 5, 6, 7, 8, 9, 10e6, time.Local)
+
+	for _, tt := range []struct {
+		name  string
+		exist []string
+		want  string
+	}{
+		{"free", nil, path + ".20240506T070809.010"},
+		{"taken", []string{".20240506T070809.010"}, path + ".20240506T070809.011"},
+		{"several taken", []string{".20240506T070809.011", ".20240506T070809.012"}, path + ".20240506T070809.013"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, suffix := range tt.exist {
+				if err := os.WriteFile(path+suffix, nil, 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			got := r.backupName(at)
+			if got != tt.want {
+				t.Fatalf("backupName = %q, want %q", got, tt.want)
+			}
+			if err := os.WriteFile(got, nil, 0o644); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestRotatingFilePrune(t *testing.T) {
+	backups := []string{".20240101T000000.000", ".20240102T000000.000", ".20240103T000000.000"}
+	for _, tt := range []struct {
+		name       string
+		maxBackups int
+		want       []string
+	}{
+		{"keep all", 0, backups},
+		{"keep more than present", 5, backups},
+		{"keep newest", 2, backups[1:]},
+		{"keep one", 1, backups[2:]},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "app.log")
+			// Files that are not timestamped backups are left alone.
+			others := []string{"app.log", "app.log.gz", "app.log.old"}
+			for _, name := range others {
+				if err := os.WriteFile(filepath.Join(dir, name), nil, 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			for _, suffix := range backups {
+				if err := os.WriteFile(path+suffix, nil, 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			r := &RotatingFile{path: path, opts: RotateOptions{MaxBackups: tt.maxBackups}}
+			if err := r.prune(); err != nil {
+				t.Fatal(err)
+			}
+
+			entries, err := os.ReadDir(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, e := range entries {
+				got = append(got, e.Name())
+			}
+			want := slices.Clone(others)
+			for _, suffix := range tt.want {
+				want = append(want, "app.log"+suffix)
+			}
+			slices.Sort(want)
+			if !slices.Equal(got, want) {
+				t.Fatalf("files = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestRotatingFileAgeSurvivesReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	rotated := time.Now().Add(-2 * time.Hour).Truncate(time.Millisecond)
+	if err := os.WriteFile(path+"."+rotated.Format(rotateTimeFormat), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("line\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := NewRotatingFile(path, RotateOptions{MaxAge: time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	if !r.opened.Equal(rotated) {
+		t.Fatalf("opened = %v, want the newest backup's time %v", r.opened, rotated)
+	}
+	if !r.due(1) {
+		t.Fatal("file older than MaxAge is not due after reopening")
+	}
+}
@@ -0,0 +1,44 @@
+package sgsr
+
+import (
+	"runtime"
+	"time"
+)
+
+type RuntimeStats struct {
+	Goroutines      int           `json:"goroutines"`
+	HeapInUse       uint64        `json:"heap_in_use"`
+	NumGC           uint32        `json:"num_gc"`
+	LastGCPause     time.Duration `json:"last_gc_pause_ns"`
+	TotalGCPause    time.Duration `json:"total_gc_pause_ns"`
+	Uptime          time.Duration `json:"uptime_ns"`
+	InFlight        int           `json:"in_flight"`
+	OpenConnections int           `json:"open_connections"`
+}
+
+// WithVerboseHealth adds RuntimeStats to the health endpoint response.
+func (c Config) WithVerboseHealth() Config {
+	c.verboseHealth = true
+	return c
+}
+
+func (a *App) RuntimeStats() RuntimeStats {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+
+	stats := RuntimeStats{
+		Goroutines:      runtime.NumGoroutine(),
+		HeapInUse:       m.HeapInuse,
+		NumGC:           m.NumGC,
+		TotalGCPause:    time.Duration(m.PauseTotalNs),
+		InFlight:        a.InFlight(),
+		OpenConnections: a.OpenConnections(),
+	}
+	if m.NumGC > 0 {
+		stats.LastGCPause = time.Duration(m.PauseNs[(m.NumGC+255)%256])
+	}
+	if !a.startedAt.IsZero() {
+		stats.Uptime = time.Since(a.startedAt)
+	}
+	return stats
+}
@@ -0,0 +1,117 @@
+package sgsr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+)
+
+// Server is a server run next to the HTTP server on its own listener; a
+// *grpc.Server satisfies it.
+type Server interface {
+	Serve(ln net.Listener) error
+	GracefulStop()
+	Stop()
+}
+
+type hostedServer struct {
+	name   string
+	addr   string
+	srv    Server
+	ln     net.Listener
+	served chan error
+}
+
+// Host serves srv on addr for as long as the app runs. It is bound after the
+// HTTP listener and stopped with GracefulStop while the HTTP server drains,
+// under the same shutdown timeout; once that expires it is stopped with Stop.
+// A Serve error stops the app. Hosted servers do not support prefork.
+func (a *App) Host(name, addr string, srv Server) {
+	a.servers = append(a.servers, &hostedServer{name: name, addr: addr, srv: srv})
+}
+
+func (a *App) listenServers() error {
+	if len(a.servers) > 0 && a.cfg.prefork > 0 {
+		return errors.New("sgsr: hosted servers do not support prefork")
+	}
+	for i, h := range a.servers {
+		ln, err := net.Listen("tcp", h.addr)
+		if err != nil {
+			for _, h := range a.servers[:i] {
+				_ = h.ln.Close()
+				h.ln = nil
+			}
+			return fmt.Errorf("sgsr: listen %s: %w", h.name, err)
+		}
+		h.ln = ln
+	}
+
+	return nil
+}
+
+func (a *App) serveServers() {
+	for _, h := range a.servers {
+		h.served = make(chan error, 1)
+		a.cfg.logger.Info("Status", "server", h.name, "Listening addr", h.ln.Addr().String())
+		go func() {
+			err := h.srv.Serve(h.ln)
+			if err != nil && a.ctx.Err() == nil {
+				a.cfg.logger.Error("Server failed", "server", h.name, "error", err)
+				err = fmt.Errorf("%w: %s: %w", ErrServe, h.name, err)
+				a.stopWith(CauseListener, err)
+			} else {
+				err = nil
+			}
+			h.served <- err
+		}()
+	}
+}
+
+// stopServers gracefully stops the hosted servers in the background and
+// falls back to Stop when ctx expires. The returned channel yields their
+// errors once every Serve has returned.
+func (a *App) stopServers(ctx context.Context) <-chan error {
+	done := make(chan error, 1)
+	go func() {
+		var wg sync.WaitGroup
+		for _, h := range a.servers {
+			if h.served == nil {
+				continue
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				h.srv.GracefulStop()
+			}()
+		}
+		stopped := make(chan struct{})
+		go func() {
+			wg.Wait()
+			close(stopped)
+		}()
+
+		var errs []error
+		select {
+		case <-stopped:
+		case <-ctx.Done():
+			a.cfg.logger.Error("Exit hosted servers by shut down timeout")
+			for _, h := range a.servers {
+				if h.served != nil {
+					h.srv.Stop()
+				}
+			}
+			<-stopped
+			errs = append(errs, fmt.Errorf("%w: hosted servers stopped forcibly", ErrShutdownTimeout))
+		}
+		for _, h := range a.servers {
+			if h.served != nil {
+				errs = append(errs, <-h.served)
+			}
+		}
+		done <- errors.Join(errs...)
+	}()
+
+	return done
+}
@@ -0,0 +1,72 @@
+package sgsr
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+// blockingServer accepts nothing and returns from Serve once stopped;
+// GracefulStop blocks until Stop when hang is set.
+type blockingServer struct {
+	hang     bool
+	stop     chan struct{}
+	stopped  chan struct{}
+	graceful bool
+}
+
+func newBlockingServer(hang bool) *blockingServer {
+	return &blockingServer{hang: hang, stop: make(chan struct{}), stopped: make(chan struct{})}
+}
+
+func (s *blockingServer) Serve(ln net.Listener) error {
+	<-s.stopped
+	return ln.Close()
+}
+
+func (s *blockingServer) GracefulStop() {
+	if s.hang {
+		<-s.stop
+		return
+	}
+	s.graceful = true
+	close(s.stopped)
+}
+
+func (s *blockingServer) Stop() {
+	close(s.stop)
+	if s.hang {
+		close(s.stopped)
+	}
+}
+
+func TestHostedServerStopsGracefully(t *testing.T) {
+	a, _ := newTestApp(t, nil)
+	srv := newBlockingServer(false)
+	a.Host("rpc", "127.0.0.1:0", srv)
+
+	if err := a.Start(); err != nil {
+		t.Fatal(err)
+	}
+	if err := stopApp(t, a); err != nil {
+		t.Fatal(err)
+	}
+	if !srv.graceful {
+		t.Fatal("hosted server was not stopped gracefully")
+	}
+}
+
+func TestHostedServerStoppedAfterShutdownTimeout(t *testing.T) {
+	a, _ := newTestApp(t, func(c Config) Config {
+		return c.WithShutdownTimeout(50 * time.Millisecond)
+	})
+	a.Host("rpc", "127.0.0.1:0", newBlockingServer(true))
+
+	if err := a.Start(); err != nil {
+		t.Fatal(err)
+	}
+	if err := stopApp(t, a); !errors.Is(err, ErrShutdownTimeout) {
+		t.Fatalf("Stop() = %v, want ErrShutdownTimeout", err)
+	}
+}
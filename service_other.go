@@ -0,0 +1,9 @@
+//go:build !windows
+
+package sgsr
+
+// RunService runs the app under the Windows Service Control Manager; on
+// other platforms it is Run.
+func (a *App) RunService(name string) error {
+	return a.Run()
+}
@@ -0,0 +1,65 @@
+package sgsr
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows/svc"
+)
+
+// RunService runs the app under the Windows Service Control Manager as the
+// service name. It reports StartPending, Running and StopPending as the app
+// moves through its lifecycle and turns Stop and Shutdown requests into a
+// graceful shutdown. Outside the SCM it is Run.
+func (a *App) RunService(name string) error {
+	isService, err := svc.IsWindowsService()
+	if err != nil || !isService {
+		return a.Run()
+	}
+
+	a.cfg.signals = []os.Signal{}
+	h := &serviceHandler{app: a}
+	if err := svc.Run(name, h); err != nil {
+		return err
+	}
+	return h.err
+}
+
+type serviceHandler struct {
+	app *App
+	err error
+}
+
+func (h *serviceHandler) Execute(_ []string, r <-chan svc.ChangeRequest, s chan<- svc.Status) (bool, uint32) {
+	s <- svc.Status{State: svc.StartPending}
+
+	errc := make(chan error, 1)
+	go func() {
+		errc <- h.app.Run()
+	}()
+
+	running := h.app.running
+	stopping := svc.Status{
+		State:    svc.StopPending,
+		WaitHint: uint32((h.app.cfg.preShutdownDelay + h.app.cfg.shutdownTimeout).Milliseconds()),
+	}
+	for {
+		select {
+		case <-running:
+			running = nil
+			s <- svc.Status{State: svc.Running, Accepts: svc.AcceptStop | svc.AcceptShutdown}
+		case h.err = <-errc:
+			if h.err != nil {
+				return false, 1
+			}
+			return false, 0
+		case c := <-r:
+			switch c.Cmd {
+			case svc.Interrogate:
+				s <- c.CurrentStatus
+			case svc.Stop, svc.Shutdown:
+				s <- stopping
+				h.app.stopWith(CauseService, nil)
+			}
+		}
+	}
+}
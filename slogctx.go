@@ -0,0 +1,64 @@
+package sgsr
+
+import (
+	"context"
+	"log/slog"
+)
+
+// ContextExtractor pulls correlation attributes out of a context.
+type ContextExtractor func(ctx context.Context) []slog.Attr
+
+// ContextValue extracts ctx.Value(key) as the attribute name when present.
+func ContextValue(key any, name string) ContextExtractor {
+	return func(ctx context.Context) []slog.Attr {
+		if v := ctx.Value(key); v != nil {
+			return []slog.Attr{slog.Any(name, v)}
+		}
+		return nil
+	}
+}
+
+// RequestIDExtractor adds the ID set by WithRequestID. It works with the
+// fasthttp request context (c.Context()) and with contexts carrying
+// RequestIDLocal as a value.
+func RequestIDExtractor() ContextExtractor {
+	return ContextValue(RequestIDLocal, "request_id")
+}
+
+// ContextHandler adds the attributes returned by its extractors to every
+// record logged with a context, e.g. logger.InfoContext(ctx, ...). The access
+// and slow request logs pass the request context, so they pick up the
+// request ID this way; NewLogger installs it by default.
+type ContextHandler struct {
+	handler    slog.Handler
+	extractors []ContextExtractor
+}
+
+// NewContextHandler wraps h; without extractors it uses RequestIDExtractor.
+func NewContextHandler(h slog.Handler, extractors ...ContextExtractor) *ContextHandler {
+	if len(extractors) == 0 {
+		extractors = []ContextExtractor{RequestIDExtractor()}
+	}
+	return &ContextHandler{handler: h, extractors: extractors}
+}
+
+func (h *ContextHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.handler.Enabled(ctx, level)
+}
+
+func (h *ContextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if ctx != nil {
+		for _, extract := range h.extractors {
+			r.AddAttrs(extract(ctx)...)
+		}
+	}
+	return h.handler.Handle(ctx, r)
+}
+
+func (h *ContextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &ContextHandler{handler: h.handler.WithAttrs(attrs), extractors: h.extractors}
+}
+
+func (h *ContextHandler) WithGroup(name string) slog.Handler {
+	return &ContextHandler{handler: h.handler.WithGroup(name), extractors: h.extractors}
+}
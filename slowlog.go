@@ -0,0 +1,48 @@
+package sgsr
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/valyala/fasthttp"
+)
+
+// WithSlowRequestLog logs every request taking longer than threshold at
+// WARN, independently of the access log and its sampling.
+func (c Config) WithSlowRequestLog(threshold time.Duration) Config {
+	c.slowRequest = threshold
+	return c
+}
+
+func (a *App) slowRequestHandler(next fasthttp.RequestHandler) fasthttp.RequestHandler {
+	threshold := a.cfg.slowRequest
+	return func(ctx *fasthttp.RequestCtx) {
+		started := time.Now()
+		next(ctx)
+
+		elapsed := time.Since(started)
+		if elapsed < threshold {
+			return
+		}
+		a.accessLog.LogAttrs(ctx, slog.LevelWarn, "Slow request",
+			slog.String("method", string(ctx.Method())),
+			slog.String("path", string(ctx.Path())),
+			slog.Int("status", ctx.Response.StatusCode()),
+			slog.Duration("duration", elapsed),
+			slog.Duration("threshold", threshold),
+			slog.Int("query_args", ctx.QueryArgs().Len()),
+			slog.Int("request_bytes", requestSize(&ctx.Request)),
+			slog.Int("response_bytes", responseSize(&ctx.Response)),
+			slog.String("client", ctx.RemoteIP().String()),
+			slog.String("user_agent", string(ctx.UserAgent())),
+		)
+	}
+}
+
+// requestSize is responseSize for request bodies, e.g. with StreamRequestBody.
+func requestSize(req *fasthttp.Request) int {
+	if req.IsBodyStream() {
+		return req.Header.ContentLength()
+	}
+	return len(req.Body())
+}
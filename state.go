@@ -0,0 +1,70 @@
+package sgsr
+
+import (
+	"net"
+)
+
+type State int32
+
+const (
+	Starting State = iota
+	Running
+	Draining
+	Stopped
+)
+
+func (s State) String() string {
+	switch s {
+	case Starting:
+		return "starting"
+	case Running:
+		return "running"
+	case Draining:
+		return "draining"
+	case Stopped:
+		return "stopped"
+	default:
+		return "unknown"
+	}
+}
+
+func (a *App) State() State {
+	return State(a.state.Load())
+}
+
+// Done is closed once Run has returned.
+func (a *App) Done() <-chan struct{} {
+	return a.done
+}
+
+func (a *App) setState(s State) {
+	a.state.Store(int32(s))
+	if s != Stopped {
+		a.emit(Event{Type: EventType(s.String())})
+	}
+}
+
+func (a *App) setRunning() {
+	a.setState(Running)
+	close(a.running)
+}
+
+// Addr returns the address the listener is bound to, or nil before the app
+// is listening. With ":0" it carries the port that was actually chosen.
+func (a *App) Addr() net.Addr {
+	addr, _ := a.addr.Load().(net.Addr)
+	return addr
+}
+
+// Listening is closed once the listener is bound and Addr is set.
+func (a *App) Listening() <-chan struct{} {
+	return a.listening
+}
+
+func (a *App) setListening(addr net.Addr) {
+	a.addr.Store(addr)
+	a.emit(Event{Type: EventListening, Addr: addr})
+	a.listeningOnce.Do(func() {
+		close(a.listening)
+	})
+}
@@ -0,0 +1,36 @@
+package sgsr
+
+import (
+	"log/slog"
+)
+
+// WithStartupSummary logs the registered routes and effective timeouts once
+// the listener is bound.
+func (c Config) WithStartupSummary() Config {
+	c.startupSummary = true
+	return c
+}
+
+func (a *App) logStartupSummary() {
+	routes := a.cfg.app.GetRoutes(true)
+	list := make([]string, 0, len(routes))
+	for _, r := range routes {
+		list = append(list, r.Method+" "+r.Path)
+	}
+
+	fc := a.cfg.app.Config()
+	a.cfg.logger.Info("Startup summary",
+		slog.Int("routes_count", len(list)),
+		slog.Any("routes", list),
+		slog.Group("timeouts",
+			slog.Duration("read", fc.ReadTimeout),
+			slog.Duration("write", fc.WriteTimeout),
+			slog.Duration("idle", fc.IdleTimeout),
+			slog.Duration("startup", a.cfg.startupTimeout),
+			slog.Duration("pre_shutdown_delay", a.cfg.preShutdownDelay),
+			slog.Duration("shutdown", a.cfg.shutdownTimeout),
+		),
+		slog.Int("body_limit", fc.BodyLimit),
+		slog.Bool("tls", a.cfg.tlsEnabled()),
+	)
+}
@@ -0,0 +1,83 @@
+//go:build !windows && !plan9
+
+package sgsr
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"log/syslog"
+	"strings"
+	"sync"
+)
+
+// SyslogHandler writes records in text form, without time and level, to a
+// syslog writer at the priority matching their level.
+type SyslogHandler struct {
+	w   *syslog.Writer
+	mu  *sync.Mutex
+	buf *bytes.Buffer
+	h   slog.Handler
+}
+
+func NewSyslogHandler(w *syslog.Writer, opts *slog.HandlerOptions) *SyslogHandler {
+	ho := slog.HandlerOptions{}
+	if opts != nil {
+		ho = *opts
+	}
+	replace := ho.ReplaceAttr
+	ho.ReplaceAttr = func(groups []string, a slog.Attr) slog.Attr {
+		if len(groups) == 0 && (a.Key == slog.TimeKey || a.Key == slog.LevelKey) {
+			return slog.Attr{}
+		}
+		if replace != nil {
+			return replace(groups, a)
+		}
+		return a
+	}
+
+	buf := &bytes.Buffer{}
+	return &SyslogHandler{w: w, mu: &sync.Mutex{}, buf: buf, h: slog.NewTextHandler(buf, &ho)}
+}
+
+func syslogHandler(opts *slog.HandlerOptions) (slog.Handler, error) {
+	w, err := syslog.New(syslog.LOG_INFO|syslog.LOG_DAEMON, "")
+	if err != nil {
+		return nil, err
+	}
+	return NewSyslogHandler(w, opts), nil
+}
+
+func (h *SyslogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.h.Enabled(ctx, level)
+}
+
+func (h *SyslogHandler) Handle(ctx context.Context, r slog.Record) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.buf.Reset()
+	if err := h.h.Handle(ctx, r); err != nil {
+		return err
+	}
+	line := strings.TrimSuffix(h.buf.String(), "\n")
+
+	switch {
+	case r.Level >= slog.LevelError:
+		return h.w.Err(line)
+	case r.Level >= slog.LevelWarn:
+		return h.w.Warning(line)
+	case r.Level >= slog.LevelInfo:
+		return h.w.Info(line)
+	default:
+		return h.w.Debug(line)
+	}
+}
+
+func (h *SyslogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &SyslogHandler{w: h.w, mu: h.mu, buf: h.buf, h: h.h.WithAttrs(attrs)}
+}
+
+func (h *SyslogHandler) WithGroup(name string) slog.Handler {
+	return &SyslogHandler{w: h.w, mu: h.mu, buf: h.buf, h: h.h.WithGroup(name)}
+}
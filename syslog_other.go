@@ -0,0 +1,12 @@
+//go:build windows || plan9
+
+package sgsr
+
+import (
+	"errors"
+	"log/slog"
+)
+
+func syslogHandler(*slog.HandlerOptions) (slog.Handler, error) {
+	return nil, errors.New("sgsr: syslog is not supported on this platform")
+}
@@ -0,0 +1,49 @@
+package sgsr
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RequestTimeout is route middleware that sets c.UserContext to a child of
+// App.Context bounded by d (no bound when d <= 0), so handlers observe both
+// their deadline and the start of shutdown. With WithRequestContext it
+// derives from the context set up there instead. A handler returning the context
+// error is answered with 504 when the deadline passed, as ProblemErrorHandler
+// does, and 503 when shutdown cancelled the request.
+func (a *App) RequestTimeout(d time.Duration) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		parent := a.ctx
+		if a.cfg.requestContext != nil {
+			parent = c.UserContext()
+		} else if id := RequestID(c); id != "" {
+			parent = context.WithValue(parent, RequestIDLocal, id)
+		}
+
+		var (
+			ctx    context.Context
+			cancel context.CancelFunc
+		)
+		if d > 0 {
+			ctx, cancel = context.WithTimeout(parent, d)
+		} else {
+			ctx, cancel = context.WithCancel(parent)
+		}
+		defer cancel()
+		c.SetUserContext(ctx)
+
+		err := c.Next()
+		switch {
+		case !errors.Is(err, context.DeadlineExceeded) && !errors.Is(err, context.Canceled):
+			return err
+		case errors.Is(ctx.Err(), context.DeadlineExceeded):
+			return fiber.ErrGatewayTimeout
+		case ctx.Err() != nil:
+			return fiber.ErrServiceUnavailable
+		}
+		return err
+	}
+}
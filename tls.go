@@ -0,0 +1,58 @@
+package sgsr
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+)
+
+func (c Config) WithTLS(certFile, keyFile string) Config {
+	c.certFile = certFile
+	c.keyFile = keyFile
+	return c
+}
+
+func (c Config) WithTLSConfig(cfg *tls.Config) Config {
+	c.tlsConfig = cfg
+	return c
+}
+
+func (c Config) tlsEnabled() bool {
+	return c.tlsConfig != nil || c.certFile != "" || c.getCertificate != nil
+}
+
+func (a *App) buildTLSConfig(ctx context.Context) (*tls.Config, error) {
+	c := a.cfg
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	if c.tlsConfig != nil {
+		cfg = c.tlsConfig.Clone()
+	}
+
+	switch {
+	case c.getCertificate != nil:
+		cfg.GetCertificate = c.getCertificate
+	case c.certFile != "":
+		r, err := newCertReloader(a.tlsLog, c.certFile, c.keyFile)
+		if err != nil {
+			return nil, err
+		}
+		if c.tlsReload > 0 {
+			go r.watch(ctx, c.tlsReload)
+		}
+		a.certs = r
+		cfg.GetCertificate = r.getCertificate
+	}
+	c.applyClientAuth(cfg)
+
+	return cfg, nil
+}
+
+func (a *App) tlsListener(ctx context.Context, ln net.Listener) (net.Listener, error) {
+	cfg, err := a.buildTLSConfig(ctx)
+	if err != nil {
+		_ = ln.Close()
+		return nil, err
+	}
+
+	return tls.NewListener(ln, cfg), nil
+}
@@ -0,0 +1,152 @@
+package sgsr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strconv"
+	"time"
+)
+
+const (
+	envListenerFD = "SGSR_LISTENER_FD"
+	envReadyFD    = "SGSR_READY_FD"
+
+	upgradeReadyTimeout = time.Minute
+)
+
+var (
+	errNoListenerFile = errors.New("upgrade: listener does not support fd passing")
+	errChildNotReady  = errors.New("upgrade: new process exited before becoming ready")
+)
+
+// WithUpgradeSignal enables zero-downtime restarts: on sig (typically
+// syscall.SIGUSR2) the current executable is started again with the
+// listening socket and environment inherited. Once the new process reports
+// Running, this process drains and exits; if it never does, the new process
+// is killed and this one keeps serving.
+func (c Config) WithUpgradeSignal(sig os.Signal) Config {
+	c.upgradeSignal = sig
+	return c
+}
+
+func inheritedListener() (net.Listener, error) {
+	value := os.Getenv(envListenerFD)
+	if value == "" {
+		return nil, nil
+	}
+	_ = os.Unsetenv(envListenerFD)
+
+	fd, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, fmt.Errorf("upgrade: invalid %s: %w", envListenerFD, err)
+	}
+
+	f := os.NewFile(uintptr(fd), "listener")
+	defer f.Close()
+
+	return net.FileListener(f)
+}
+
+func (a *App) watchUpgrade(ctx context.Context) {
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, a.cfg.upgradeSignal)
+	defer signal.Stop(sigs)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-sigs:
+			pid, err := a.upgrade()
+			if err != nil {
+				a.cfg.logger.Error("Upgrade failed", "error", err)
+				continue
+			}
+			a.cfg.logger.Info("New process is ready, draining old process", "pid", pid)
+			a.stopWith(CauseUpgrade, nil)
+			return
+		}
+	}
+}
+
+func (a *App) upgrade() (int, error) {
+	fl, ok := a.tcp.(interface{ File() (*os.File, error) })
+	if !ok {
+		return 0, errNoListenerFile
+	}
+
+	f, err := fl.File()
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	exe, err := os.Executable()
+	if err != nil {
+		return 0, err
+	}
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		return 0, err
+	}
+	defer r.Close()
+
+	cmd := exec.Command(exe, os.Args[1:]...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	cmd.Env = append(os.Environ(), envListenerFD+"=3", envReadyFD+"=4")
+	cmd.ExtraFiles = []*os.File{f, w}
+	err = cmd.Start()
+	_ = w.Close()
+	if err != nil {
+		return 0, err
+	}
+
+	pid := cmd.Process.Pid
+	a.cfg.logger.Info("Waiting for new process to become ready", "pid", pid)
+	if err := waitReady(r); err != nil {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+		return 0, err
+	}
+
+	return pid, cmd.Process.Release()
+}
+
+func waitReady(r *os.File) error {
+	_ = r.SetReadDeadline(time.Now().Add(upgradeReadyTimeout))
+
+	buf := make([]byte, 1)
+	if _, err := r.Read(buf); err != nil {
+		if errors.Is(err, io.EOF) {
+			return errChildNotReady
+		}
+		return fmt.Errorf("upgrade: %w", err)
+	}
+	return nil
+}
+
+// notifyUpgradeReady tells the parent of an in-place upgrade that this
+// process is serving.
+func notifyUpgradeReady() {
+	value := os.Getenv(envReadyFD)
+	if value == "" {
+		return
+	}
+	_ = os.Unsetenv(envReadyFD)
+
+	fd, err := strconv.Atoi(value)
+	if err != nil {
+		return
+	}
+
+	f := os.NewFile(uintptr(fd), "ready")
+	_, _ = f.Write([]byte{1})
+	_ = f.Close()
+}
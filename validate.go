@@ -0,0 +1,94 @@
+package sgsr
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// Validate reports every configuration problem at once, joined with
+// errors.Join. Run calls it before binding and fails with ErrStartup.
+func (c Config) Validate() error {
+	var errs []error
+	add := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf("sgsr: "+format, args...))
+	}
+
+	if c.app == nil {
+		add("fiber app is nil")
+	}
+	if c.logger == nil {
+		add("logger is nil")
+	}
+	for _, addr := range append([]string{c.addr}, c.fallbackAddrs...) {
+		if addr == "" {
+			continue
+		}
+		if _, _, err := net.SplitHostPort(addr); err != nil {
+			add("invalid address %q: %w", addr, err)
+		}
+	}
+	if c.bindRetry.Delay < 0 {
+		add("bind retry delay must not be negative")
+	}
+
+	if (c.certFile == "") != (c.keyFile == "") {
+		add("certificate and key files must be set together")
+	}
+	if c.mutualTLS() && !c.tlsEnabled() {
+		add("client certificate verification requires TLS")
+	}
+	if c.tlsReload < 0 {
+		add("TLS reload interval must not be negative")
+	}
+	if c.tlsReload > 0 && c.certFile == "" {
+		add("TLS reload requires certificate files")
+	}
+
+	if c.shutdownTimeout <= 0 {
+		add("shutdown timeout must be positive")
+	}
+	if c.startupTimeout < 0 {
+		add("startup timeout must not be negative")
+	}
+	if c.preShutdownDelay < 0 {
+		add("pre-shutdown delay must not be negative")
+	}
+	if c.slowRequest < 0 {
+		add("slow request threshold must not be negative")
+	}
+	if c.prefork < 0 {
+		add("prefork count must not be negative")
+	}
+	if c.restart != nil && c.restart.MaxAttempts < 0 {
+		add("restart policy max attempts must not be negative")
+	}
+
+	for _, p := range [...]struct{ name, path string }{
+		{"liveness", c.livenessPath},
+		{"readiness", c.readinessPath},
+		{"startup", c.startupPath},
+		{"health", c.healthPath},
+	} {
+		if p.path != "" && !strings.HasPrefix(p.path, "/") {
+			add("%s path %q must start with /", p.name, p.path)
+		}
+	}
+
+	if c.accessLog != nil {
+		if r := c.accessLog.DefaultRate; r < 0 || r > 1 {
+			add("access log default rate %v is outside [0, 1]", r)
+		}
+		for i, r := range c.accessLog.Rules {
+			if r.Rate < 0 || r.Rate > 1 {
+				add("access log rule %d rate %v is outside [0, 1]", i, r.Rate)
+			}
+		}
+	}
+	if c.hsts != nil && c.hsts.MaxAge < 0 {
+		add("HSTS max age must not be negative")
+	}
+
+	return errors.Join(errs...)
+}
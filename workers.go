@@ -0,0 +1,99 @@
+package sgsr
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+type worker struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+type workers struct {
+	wg      sync.WaitGroup
+	mu      sync.Mutex
+	pending []worker
+	started bool
+	errs    []error
+}
+
+// WithStopOnWorkerError controls whether a worker returning an error shuts
+// the app down (the default) or is only logged.
+func (c Config) WithStopOnWorkerError(stop bool) Config {
+	c.stopOnWorkerError = stop
+	return c
+}
+
+// Context returns the app lifecycle context, cancelled when graceful
+// shutdown starts closing connections.
+func (a *App) Context() context.Context {
+	return a.ctx
+}
+
+// Go runs fn in a goroutine tied to the app lifecycle. Workers registered
+// before Run start once the app is Running; fn receives App.Context and
+// shutdown waits for it within the shutdown timeout.
+func (a *App) Go(name string, fn func(ctx context.Context) error) {
+	a.workers.mu.Lock()
+	defer a.workers.mu.Unlock()
+
+	w := worker{name: name, fn: fn}
+	if !a.workers.started {
+		a.workers.pending = append(a.workers.pending, w)
+		return
+	}
+	a.startWorker(w)
+}
+
+func (a *App) startWorkers() {
+	a.workers.mu.Lock()
+	defer a.workers.mu.Unlock()
+
+	a.workers.started = true
+	for _, w := range a.workers.pending {
+		a.startWorker(w)
+	}
+	a.workers.pending = nil
+}
+
+func (a *App) startWorker(w worker) {
+	a.workers.wg.Add(1)
+	go func() {
+		defer a.workers.wg.Done()
+
+		err := w.fn(a.ctx)
+		if err == nil || errors.Is(err, context.Canceled) && a.ctx.Err() != nil {
+			a.cfg.logger.Debug("Worker stopped", "worker", w.name)
+			return
+		}
+
+		a.cfg.logger.Error("Worker failed", "worker", w.name, "error", err)
+		a.workers.mu.Lock()
+		a.workers.errs = append(a.workers.errs, &HookError{Phase: "worker", Name: w.name, Err: err})
+		a.workers.mu.Unlock()
+
+		if a.cfg.stopOnWorkerError {
+			a.stopWith(CauseWorker, err)
+		}
+	}()
+}
+
+func (a *App) waitWorkers(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		a.workers.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		a.cfg.logger.Error("Workers did not stop within the shutdown timeout")
+	}
+
+	a.workers.mu.Lock()
+	defer a.workers.mu.Unlock()
+	return errors.Join(a.workers.errs...)
+}